@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log/slog"
 	"net/http"
 	"os"
@@ -19,8 +20,11 @@ import (
 	"github.com/pokt-network/sam/internal/cache"
 	"github.com/pokt-network/sam/internal/config"
 	"github.com/pokt-network/sam/internal/handler"
+	"github.com/pokt-network/sam/internal/history"
 	"github.com/pokt-network/sam/internal/models"
+	"github.com/pokt-network/sam/internal/pendingtx"
 	"github.com/pokt-network/sam/internal/pocket"
+	"github.com/pokt-network/sam/internal/simulate"
 )
 
 var version = "dev"
@@ -29,6 +33,10 @@ func main() {
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
 	logger.Info("starting SAM", "version", version)
 
+	simulateFlag := flag.Bool("simulate", false, "run against a built-in simulated chain (no network, keys or pocketd)")
+	flag.Parse()
+	simulated := *simulateFlag || os.Getenv("SAM_SIMULATE") == "1"
+
 	configPath := os.Getenv("CONFIG_FILE")
 	if configPath == "" {
 		configPath = "config.yaml"
@@ -39,10 +47,42 @@ func main() {
 		dataDir = "."
 	}
 
-	cfg, err := config.Load(configPath)
-	if err != nil {
-		logger.Error("failed to load config", "error", err)
-		os.Exit(1)
+	// Background goroutines (worker, tracker, simulation) stop on this context.
+	workerCtx, workerCancel := context.WithCancel(context.Background())
+	defer workerCancel()
+
+	var (
+		cfg      *config.Config
+		executor pocket.TxSubmitter
+		sim      *simulate.Simulation
+	)
+
+	if simulated {
+		// Nothing touches config.yaml or the data directory in simulation.
+		sim = simulate.New(logger)
+		apiURL, err := sim.Start(workerCtx)
+		if err != nil {
+			logger.Error("failed to start simulated chain", "error", err)
+			os.Exit(1)
+		}
+		cfg = sim.Config(apiURL)
+		configPath = ""
+		dataDir, err = os.MkdirTemp("", "sam-simulate-*")
+		if err != nil {
+			logger.Error("failed to create simulation data dir", "error", err)
+			os.Exit(1)
+		}
+		defer os.RemoveAll(dataDir)
+
+		logger.Info("SIMULATION MODE: using an in-memory chain; no transactions reach a real network",
+			"api", apiURL, "applications", len(sim.Apps))
+	} else {
+		var err error
+		cfg, err = config.Load(configPath)
+		if err != nil {
+			logger.Error("failed to load config", "error", err)
+			os.Exit(1)
+		}
 	}
 
 	networks := make([]string, 0, len(cfg.Config.Networks))
@@ -51,17 +91,51 @@ func main() {
 	}
 	logger.Info("configuration loaded", "networks", networks)
 
-	if path, err := exec.LookPath("pocketd"); err != nil {
-		logger.Warn("pocketd not found in PATH; transactions will fail")
-	} else {
-		logger.Info("pocketd found", "path", path)
+	pendingStore, err := pendingtx.NewStore(filepath.Join(dataDir, "pending.json"))
+	if err != nil {
+		logger.Error("failed to initialize pending transaction store", "error", err)
+		os.Exit(1)
+	}
+	if cfg.Config.PendingTTL > 0 {
+		pendingStore.TTL = cfg.Config.PendingTTL
 	}
 
 	client := pocket.NewClient(logger)
-	executor := pocket.NewExecutor(cfg, client, logger)
+	if cfg.Config.QueryTimeout > 0 {
+		client.HTTP.Timeout = cfg.Config.QueryTimeout
+	}
+	client.Retry = pocket.RetryPolicyFromConfig(cfg)
+	client.Endpoints = pocket.NewEndpoints(cfg)
+
+	if simulated {
+		executor = sim.Chain
+	} else {
+		if path, err := exec.LookPath("pocketd"); err != nil {
+			logger.Warn("pocketd not found in PATH; transactions will fail")
+		} else {
+			logger.Info("pocketd found", "path", path)
+		}
 
-	appCache := cache.New[[]models.Application](1 * time.Minute)
+		pocketdExecutor := pocket.NewExecutor(cfg, client, pendingStore, logger)
+		pocketdExecutor.Endpoints = client.Endpoints
+		if err := pocketdExecutor.CheckKeyring(workerCtx); err != nil {
+			logger.Error("keyring check failed; transactions will fail until it can be unlocked", "error", err)
+		} else {
+			logger.Info("keyring unlocked", "backend", cfg.Config.KeyringBackend)
+		}
+		go pocketdExecutor.RunKeyringChecks(workerCtx, pocket.DefaultKeyringCheckInterval)
+		executor = pocketdExecutor
+	}
+
+	appCache := cache.New[models.ApplicationsResponse](1 * time.Minute)
 	bankCache := cache.New[models.BankAccount](1 * time.Minute)
+	// Last known application values, shown as stale while a query fails.
+	lastGoodApps := cache.New[models.Application](24 * time.Hour)
+	// Discovery reports outlive one discovery interval, so a slow run never
+	// leaves the cache empty.
+	discoveryCache := cache.New[models.DiscoveryReport](2 * handler.DefaultDiscoveryInterval)
+	serviceCache := cache.New[[]models.ServiceInfo](handler.ServiceCacheTTL)
+	paramsCache := cache.New[models.ChainParams](handler.ParamsCacheTTL)
 
 	topUpStore, err := autotopup.NewStore(filepath.Join(dataDir, "autotopup.json"))
 	if err != nil {
@@ -69,18 +143,51 @@ func main() {
 		os.Exit(1)
 	}
 
-	worker := autotopup.NewWorker(topUpStore, cfg, client, executor, appCache, bankCache, logger)
+	sweepStore, err := autotopup.NewSweepStore(filepath.Join(dataDir, "sweep.json"))
+	if err != nil {
+		logger.Error("failed to initialize sweep store", "error", err)
+		os.Exit(1)
+	}
+
+	historyStore, err := history.NewStore(filepath.Join(dataDir, "history.json"), history.PolicyFromConfig(cfg))
+	if err != nil {
+		logger.Error("failed to initialize history store", "error", err)
+		os.Exit(1)
+	}
+
+	if simulated {
+		for address, topUp := range sim.AutoTopUps() {
+			if err := topUpStore.Set(simulate.Network, address, topUp); err != nil {
+				logger.Warn("failed to seed simulated auto top-up", "address", address, "error", err)
+			}
+		}
+	}
+
+	worker := autotopup.NewWorker(topUpStore, sweepStore, cfg, client, executor, pendingStore, appCache, bankCache, logger)
+	tracker := pendingtx.NewTracker(pendingStore, cfg, client, logger)
+	if simulated {
+		worker.Interval = time.Minute
+	}
 
 	srv := &handler.Server{
-		Config:     cfg,
-		ConfigPath: configPath,
-		Client:     client,
-		Executor:   executor,
-		AppCache:   appCache,
-		BankCache:  bankCache,
-		AutoTopUp:  topUpStore,
-		Worker:     worker,
-		Logger:     logger,
+		Config:       cfg,
+		ConfigPath:   configPath,
+		Client:       client,
+		Executor:     executor,
+		AppCache:     appCache,
+		LastGood:     lastGoodApps,
+		BankCache:    bankCache,
+		Discovery:    discoveryCache,
+		ServiceCache: serviceCache,
+		ParamsCache:  paramsCache,
+		AutoTopUp:    topUpStore,
+		Sweeps:       sweepStore,
+		Pending:      pendingStore,
+		History:      historyStore,
+		Worker:       worker,
+		Endpoints:    client.Endpoints,
+		Logger:       logger,
+		Simulated:    simulated,
 	}
 
 	r := mux.NewRouter()
@@ -114,8 +221,19 @@ func main() {
 	}
 
 	// Start auto-top-up worker.
-	workerCtx, workerCancel := context.WithCancel(context.Background())
 	go worker.Run(workerCtx)
+	go tracker.Run(workerCtx)
+	go client.RunEndpointProbes(workerCtx, pocket.DefaultProbeInterval)
+	go srv.RunRefresher(workerCtx, handler.DefaultRefreshInterval)
+	go srv.RunDiscovery(workerCtx, handler.DefaultDiscoveryInterval)
+	for name, netCfg := range cfg.Config.Networks {
+		if netCfg.Subscribe {
+			go pocket.NewSubscriber(cfg, name, client.Endpoints, srv.OnChainEvent, logger).Run(workerCtx)
+		}
+	}
+	if simulated {
+		go sim.Run(workerCtx)
+	}
 
 	// Graceful shutdown.
 	done := make(chan struct{})
@@ -148,5 +266,6 @@ func main() {
 	}
 
 	<-done
+	srv.FlushHistory()
 	logger.Info("server stopped")
 }
@@ -4,6 +4,7 @@ go 1.25.3
 
 require (
 	github.com/gorilla/mux v1.8.1
+	github.com/gorilla/websocket v1.5.3
 	github.com/rs/cors v1.11.1
 	gopkg.in/yaml.v3 v3.0.1
 )
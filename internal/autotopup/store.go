@@ -1,12 +1,10 @@
 package autotopup
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
 	"sync"
 
+	"github.com/pokt-network/sam/internal/jsonfile"
 	"github.com/pokt-network/sam/internal/models"
 )
 
@@ -27,22 +25,8 @@ func NewStore(path string) (*Store, error) {
 		data: make(StoreData),
 	}
 
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		if err := s.save(); err != nil {
-			return nil, fmt.Errorf("failed to create autotopup file: %w", err)
-		}
-		return s, nil
-	}
-
-	raw, err := os.ReadFile(path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read autotopup file: %w", err)
-	}
-
-	if len(raw) > 0 {
-		if err := json.Unmarshal(raw, &s.data); err != nil {
-			return nil, fmt.Errorf("failed to parse autotopup file: %w", err)
-		}
+	if err := jsonfile.Load(path, &s.data); err != nil {
+		return nil, err
 	}
 
 	return s, nil
@@ -128,36 +112,5 @@ func (s *Store) Delete(network, address string) error {
 
 // save writes data atomically (temp file + rename).
 func (s *Store) save() error {
-	raw, err := json.MarshalIndent(s.data, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal autotopup data: %w", err)
-	}
-
-	dir := filepath.Dir(s.path)
-	tmp, err := os.CreateTemp(dir, "autotopup-*.tmp")
-	if err != nil {
-		return fmt.Errorf("failed to create temp file: %w", err)
-	}
-
-	if _, err := tmp.Write(raw); err != nil {
-		tmp.Close()
-		os.Remove(tmp.Name())
-		return fmt.Errorf("failed to write temp file: %w", err)
-	}
-	if err := tmp.Sync(); err != nil {
-		tmp.Close()
-		os.Remove(tmp.Name())
-		return fmt.Errorf("failed to sync temp file: %w", err)
-	}
-	if err := tmp.Close(); err != nil {
-		os.Remove(tmp.Name())
-		return fmt.Errorf("failed to close temp file: %w", err)
-	}
-
-	if err := os.Rename(tmp.Name(), s.path); err != nil {
-		os.Remove(tmp.Name())
-		return fmt.Errorf("failed to rename temp file: %w", err)
-	}
-
-	return nil
+	return jsonfile.WriteAtomic(s.path, s.data)
 }
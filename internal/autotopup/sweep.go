@@ -0,0 +1,126 @@
+package autotopup
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/pokt-network/sam/internal/jsonfile"
+	"github.com/pokt-network/sam/internal/models"
+	"github.com/pokt-network/sam/internal/pocket"
+)
+
+// SweepFeeReserve is the uPOKT kept back from a sweep to pay the send fee.
+const SweepFeeReserve = pocket.TxFee
+
+// SweepData maps network -> address -> sweep policy.
+type SweepData map[string]map[string]models.SweepConfig
+
+// SweepStore provides thread-safe persistence for per-app sweep policies.
+type SweepStore struct {
+	mu   sync.RWMutex
+	path string
+	data SweepData
+}
+
+// NewSweepStore loads or creates a sweep policy file.
+func NewSweepStore(path string) (*SweepStore, error) {
+	s := &SweepStore{
+		path: path,
+		data: make(SweepData),
+	}
+
+	if err := jsonfile.Load(path, &s.data); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Get returns the sweep policy for a specific app on a network.
+func (s *SweepStore) Get(network, address string) (models.SweepConfig, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if net, ok := s.data[network]; ok {
+		cfg, ok := net[address]
+		return cfg, ok
+	}
+	return models.SweepConfig{}, false
+}
+
+// GetAll returns all sweep policies for a network.
+func (s *SweepStore) GetAll(network string) map[string]models.SweepConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make(map[string]models.SweepConfig)
+	if net, ok := s.data[network]; ok {
+		for k, v := range net {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// GetEnabled returns all enabled sweep policies across all networks.
+func (s *SweepStore) GetEnabled() map[string]map[string]models.SweepConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make(map[string]map[string]models.SweepConfig)
+	for network, apps := range s.data {
+		for addr, cfg := range apps {
+			if cfg.Enabled {
+				if result[network] == nil {
+					result[network] = make(map[string]models.SweepConfig)
+				}
+				result[network][addr] = cfg
+			}
+		}
+	}
+	return result
+}
+
+// Set stores or updates a sweep policy and persists to disk.
+func (s *SweepStore) Set(network, address string, cfg models.SweepConfig) error {
+	if cfg.Floor < 0 {
+		return fmt.Errorf("sweep floor must not be negative")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.data[network] == nil {
+		s.data[network] = make(map[string]models.SweepConfig)
+	}
+	s.data[network][address] = cfg
+	return jsonfile.WriteAtomic(s.path, s.data)
+}
+
+// Delete removes a sweep policy and persists to disk.
+func (s *SweepStore) Delete(network, address string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if net, ok := s.data[network]; ok {
+		delete(net, address)
+		if len(net) == 0 {
+			delete(s.data, network)
+		}
+	}
+	return jsonfile.WriteAtomic(s.path, s.data)
+}
+
+// SweepAmount returns how much uPOKT can be swept from an app holding liquid
+// while leaving floor behind and SweepFeeReserve for the send fee.
+// A non-positive result means there is nothing to sweep.
+func SweepAmount(liquid, floor int64) int64 {
+	// liquid and floor are non-negative, so only taking off the reserve can
+	// wrap, which would turn a huge floor into a huge sweep.
+	excess := liquid - floor
+	if excess < math.MinInt64+SweepFeeReserve {
+		return math.MinInt64
+	}
+	return excess - SweepFeeReserve
+}
@@ -0,0 +1,79 @@
+package autotopup
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/pokt-network/sam/internal/models"
+)
+
+func TestSweepStore_SetAndGet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sweep.json")
+	s, err := NewSweepStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr := "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	cfg := models.SweepConfig{Enabled: true, Floor: 5_000_000}
+	if err := s.Set("pocket", addr, cfg); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	// Reload from disk to verify persistence.
+	s2, err := NewSweepStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, ok := s2.Get("pocket", addr)
+	if !ok {
+		t.Fatal("sweep policy not persisted")
+	}
+	if got != cfg {
+		t.Errorf("Get() = %+v, want %+v", got, cfg)
+	}
+
+	enabled := s2.GetEnabled()
+	if _, ok := enabled["pocket"][addr]; !ok {
+		t.Error("GetEnabled() should include enabled policy")
+	}
+}
+
+func TestSweepStore_SetValidation(t *testing.T) {
+	s, err := NewSweepStore(filepath.Join(t.TempDir(), "sweep.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr := "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	if err := s.Set("pocket", addr, models.SweepConfig{Enabled: true, Floor: -1}); err == nil {
+		t.Error("Set() should reject a negative floor")
+	}
+	if err := s.Set("pocket", addr, models.SweepConfig{Enabled: true, Floor: 0}); err != nil {
+		t.Errorf("Set() with zero floor error = %v", err)
+	}
+}
+
+func TestSweepAmount(t *testing.T) {
+	tests := []struct {
+		name   string
+		liquid int64
+		floor  int64
+		want   int64
+	}{
+		{"above floor", 10_000_000, 1_000_000, 8_999_999},
+		{"zero floor", 100, 0, 99},
+		{"at floor", 1_000_000, 1_000_000, -1},
+		{"below floor", 500, 1_000, -501},
+		{"maximum floor", 0, math.MaxInt64, math.MinInt64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SweepAmount(tt.liquid, tt.floor); got != tt.want {
+				t.Errorf("SweepAmount(%d, %d) = %d, want %d", tt.liquid, tt.floor, got, tt.want)
+			}
+		})
+	}
+}
@@ -9,47 +9,78 @@ import (
 	"github.com/pokt-network/sam/internal/cache"
 	"github.com/pokt-network/sam/internal/config"
 	"github.com/pokt-network/sam/internal/models"
+	"github.com/pokt-network/sam/internal/pendingtx"
 	"github.com/pokt-network/sam/internal/pocket"
+	"github.com/pokt-network/sam/internal/validate"
 )
 
 const (
 	maxEvents       = 100
+	checkInterval   = 5 * time.Minute
 	pollInterval    = 10 * time.Second
 	pollMaxAttempts = 6
+	wakeCooldown    = 2 * time.Minute
 )
 
-// Worker runs periodic auto-top-up checks.
+// Worker runs periodic auto-top-up checks and sweep policies.
 type Worker struct {
 	Store     *Store
+	Sweeps    *SweepStore
 	Config    *config.Config
-	Client    *pocket.Client
-	Executor  *pocket.Executor
-	AppCache  *cache.Cache[[]models.Application]
+	Client    pocket.ChainReader
+	Executor  pocket.TxSubmitter
+	Pending   *pendingtx.Store
+	AppCache  *cache.Cache[models.ApplicationsResponse]
 	BankCache *cache.Cache[models.BankAccount]
 	Logger    *slog.Logger
 
+	// Interval is the time between cycles; PollInterval the wait between
+	// balance checks after a fund.
+	Interval     time.Duration
+	PollInterval time.Duration
+
+	// WakeCooldown is how long after a top-up transaction wake-ups for the
+	// same app are ignored, so the events of SAM's own transactions don't
+	// start a second top-up before the first one lands.
+	WakeCooldown time.Duration
+
 	mu       sync.Mutex
+	toppedUp map[string]time.Time // network/address -> last top-up attempt; guarded by mu
 	eventsMu sync.Mutex
 	events   []models.AutoTopUpEvent
+
+	wakeMu sync.Mutex
+	woken  map[string]map[string]bool // network -> addresses to check
+	wake   chan struct{}
 }
 
 // NewWorker creates a new auto-top-up worker.
-func NewWorker(store *Store, cfg *config.Config, client *pocket.Client, executor *pocket.Executor, appCache *cache.Cache[[]models.Application], bankCache *cache.Cache[models.BankAccount], logger *slog.Logger) *Worker {
+func NewWorker(store *Store, sweeps *SweepStore, cfg *config.Config, client pocket.ChainReader, executor pocket.TxSubmitter, pending *pendingtx.Store, appCache *cache.Cache[models.ApplicationsResponse], bankCache *cache.Cache[models.BankAccount], logger *slog.Logger) *Worker {
 	return &Worker{
 		Store:     store,
+		Sweeps:    sweeps,
 		Config:    cfg,
 		Client:    client,
 		Executor:  executor,
+		Pending:   pending,
 		AppCache:  appCache,
 		BankCache: bankCache,
 		Logger:    logger,
-		events:    make([]models.AutoTopUpEvent, 0, maxEvents),
+
+		Interval:     checkInterval,
+		PollInterval: pollInterval,
+		WakeCooldown: wakeCooldown,
+
+		toppedUp: make(map[string]time.Time),
+		events:   make([]models.AutoTopUpEvent, 0, maxEvents),
+		woken:    make(map[string]map[string]bool),
+		wake:     make(chan struct{}, 1),
 	}
 }
 
 // Run starts the worker loop. It blocks until ctx is cancelled.
 func (w *Worker) Run(ctx context.Context) {
-	ticker := time.NewTicker(5 * time.Minute)
+	ticker := time.NewTicker(w.Interval)
 	defer ticker.Stop()
 
 	w.Logger.Info("auto-top-up worker started")
@@ -61,6 +92,72 @@ func (w *Worker) Run(ctx context.Context) {
 			return
 		case <-ticker.C:
 			w.RunOnce(ctx)
+		case <-w.wake:
+			w.CheckWoken(ctx)
+		}
+	}
+}
+
+// Wake asks the worker to check one app's auto top-up now instead of at the
+// next cycle, such as after a chain event touched it. It never blocks.
+func (w *Worker) Wake(network, address string) {
+	w.wakeMu.Lock()
+	if w.woken[network] == nil {
+		w.woken[network] = make(map[string]bool)
+	}
+	w.woken[network][address] = true
+	w.wakeMu.Unlock()
+
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// CheckWoken runs the auto top-up of every app passed to Wake since the
+// last call. Apps without an enabled config, and apps topped up within
+// WakeCooldown, are skipped.
+func (w *Worker) CheckWoken(ctx context.Context) {
+	w.wakeMu.Lock()
+	woken := w.woken
+	w.woken = make(map[string]map[string]bool)
+	w.wakeMu.Unlock()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	enabled := w.Store.GetEnabled()
+	for network, addresses := range woken {
+		netCfg, ok := w.Config.Config.Networks[network]
+		if !ok {
+			continue
+		}
+
+		apps := make(map[string]models.AutoTopUpConfig)
+		for address := range addresses {
+			cfg, ok := enabled[network][address]
+			if !ok {
+				continue
+			}
+			if last, ok := w.toppedUp[network+"/"+address]; ok && time.Since(last) < w.WakeCooldown {
+				w.Logger.Debug("auto-top-up: woken during cooldown, skipping", "address", address)
+				continue
+			}
+			apps[address] = cfg
+		}
+		if len(apps) == 0 {
+			continue
+		}
+
+		w.Logger.Info("auto-top-up: checking woken apps", "network", network, "count", len(apps))
+		checked := w.checkApps(ctx, network, netCfg, apps)
+		for address, cfg := range apps {
+			if ctx.Err() != nil {
+				return
+			}
+			if w.processApp(ctx, network, address, cfg, netCfg, checked[address]) {
+				w.toppedUp[network+"/"+address] = time.Now()
+			}
 		}
 	}
 }
@@ -74,11 +171,16 @@ func (w *Worker) RunOnce(ctx context.Context) {
 	defer w.mu.Unlock()
 
 	enabled := w.Store.GetEnabled()
-	if len(enabled) == 0 {
+	sweeps := w.Sweeps.GetEnabled()
+	if len(enabled) == 0 && len(sweeps) == 0 {
 		return
 	}
 
-	w.Logger.Info("auto-top-up cycle starting", "networks", len(enabled))
+	w.Logger.Info("auto-top-up cycle starting", "networks", len(enabled), "sweep_networks", len(sweeps))
+
+	// Apps that submitted a top-up transaction this cycle are not swept until
+	// the next cycle, so the sweep can't race the fund/upstake pair.
+	touched := make(map[string]bool)
 
 	for network, apps := range enabled {
 		if ctx.Err() != nil {
@@ -92,19 +194,82 @@ func (w *Worker) RunOnce(ctx context.Context) {
 			continue
 		}
 
+		checked := w.checkApps(ctx, network, netCfg, apps)
+
+		for address, cfg := range apps {
+			if ctx.Err() != nil {
+				w.Logger.Info("auto-top-up cycle cancelled")
+				return
+			}
+			if w.processApp(ctx, network, address, cfg, netCfg, checked[address]) {
+				touched[network+"/"+address] = true
+				w.toppedUp[network+"/"+address] = time.Now()
+			}
+		}
+	}
+
+	for network, apps := range sweeps {
+		if ctx.Err() != nil {
+			w.Logger.Info("auto-top-up cycle cancelled")
+			return
+		}
+
+		netCfg, ok := w.Config.Config.Networks[network]
+		if !ok {
+			w.Logger.Warn("sweep: unknown network", "network", network)
+			continue
+		}
+		if netCfg.Bank == "" {
+			w.Logger.Warn("sweep: no bank configured, skipping network", "network", network)
+			continue
+		}
+
 		for address, cfg := range apps {
 			if ctx.Err() != nil {
 				w.Logger.Info("auto-top-up cycle cancelled")
 				return
 			}
-			w.processApp(ctx, network, address, cfg, netCfg)
+			if touched[network+"/"+address] {
+				w.Logger.Debug("sweep: app topped up this cycle, skipping", "address", address)
+				continue
+			}
+			w.processSweep(ctx, network, address, cfg, netCfg)
 		}
 	}
 
 	w.Logger.Info("auto-top-up cycle complete")
 }
 
-func (w *Worker) processApp(ctx context.Context, network, address string, cfg models.AutoTopUpConfig, netCfg config.NetworkConfig) {
+// appCheck is the result of querying an app at the start of a cycle.
+type appCheck struct {
+	app *models.Application
+	err error
+}
+
+// checkApps loads every app of a network up front with list queries, to
+// pick the ones below their trigger. Transactions are still submitted one
+// app at a time, since they share the bank's account sequence, so
+// processApp re-queries an app before acting on it.
+func (w *Worker) checkApps(ctx context.Context, network string, netCfg config.NetworkConfig, apps map[string]models.AutoTopUpConfig) map[string]appCheck {
+	addresses := make([]string, 0, len(apps))
+	for address := range apps {
+		addresses = append(addresses, address)
+	}
+
+	loaded, errs := pocket.LoadApplications(ctx, w.Client, w.Logger, network, netCfg, addresses)
+
+	checked := make(map[string]appCheck, len(addresses))
+	for i, address := range addresses {
+		checked[address] = appCheck{app: loaded[i], err: errs[i]}
+	}
+	return checked
+}
+
+// processApp tops up a single app if its checked stake is below the
+// trigger. Earlier apps may have taken minutes to top up, so the amounts
+// come from a fresh query of the app, not from the check. It reports
+// whether any transaction was submitted.
+func (w *Worker) processApp(ctx context.Context, network, address string, cfg models.AutoTopUpConfig, netCfg config.NetworkConfig, checked appCheck) bool {
 	event := models.AutoTopUpEvent{
 		Timestamp:    time.Now(),
 		Network:      network,
@@ -113,25 +278,38 @@ func (w *Worker) processApp(ctx context.Context, network, address string, cfg mo
 		Phase:        "check",
 	}
 
-	app, err := w.Client.QueryApplication(address, netCfg.APIEndpoint, network)
+	app, err := checked.app, checked.err
 	if err != nil {
 		w.Logger.Error("auto-top-up: failed to query app", "address", address, "error", err)
 		event.Error = err.Error()
 		w.addEvent(event)
-		return
+		return false
 	}
 
-	event.PreviousStake = app.Stake
-
 	if app.Stake >= cfg.TriggerThreshold {
 		w.Logger.Debug("auto-top-up: stake above threshold, skipping",
 			"address", address, "stake", app.Stake, "threshold", cfg.TriggerThreshold)
-		return
+		return false
+	}
+
+	// A manual upstake or fund may have landed since the check.
+	app, err = w.Client.QueryApplication(ctx, address, netCfg.APIEndpoint, network, netCfg.BaseDenom())
+	if err != nil {
+		w.Logger.Error("auto-top-up: failed to query app", "address", address, "error", err)
+		event.Error = err.Error()
+		w.addEvent(event)
+		return false
+	}
+	event.PreviousStake = app.Stake
+	if app.Stake >= cfg.TriggerThreshold {
+		w.Logger.Info("auto-top-up: stake rose above threshold since the check, skipping",
+			"address", address, "stake", app.Stake, "threshold", cfg.TriggerThreshold)
+		return false
 	}
 
 	amountNeeded := cfg.TargetAmount - app.Stake
 	if amountNeeded <= 0 {
-		return
+		return false
 	}
 
 	w.Logger.Info("auto-top-up: app needs top-up",
@@ -141,14 +319,35 @@ func (w *Worker) processApp(ctx context.Context, network, address string, cfg mo
 		"amount_needed", amountNeeded,
 	)
 
-	// Smart funding: check if the app already has enough liquid balance.
-	fundAmount := amountNeeded - app.LiquidBalance
+	// Smart funding: check if the app already has enough liquid balance,
+	// including the upstake fee unless the bank pays it via a fee grant.
+	feeBuffer := pocket.TxFee
+	if w.Executor.HasFeeGrant(ctx, address, network) {
+		feeBuffer = 0
+	}
+	required, err := validate.AmountSum(amountNeeded, feeBuffer)
+	if err != nil {
+		w.Logger.Error("auto-top-up: top-up amount out of range", "address", address, "error", err)
+		event.Error = err.Error()
+		w.addEvent(event)
+		return false
+	}
+	fundAmount := required - app.LiquidBalance
 	if fundAmount > 0 {
 		event.Phase = "fund"
+
+		// An offline bank signs out of band; wait for the outstanding fund
+		// instead of generating another one every cycle.
+		if pending, ok := w.Pending.Outstanding(network, "fund", address); ok {
+			w.Logger.Info("auto-top-up: fund awaiting offline signature, skipping",
+				"address", address, "pending_id", pending.ID, "status", pending.Status)
+			return true
+		}
+
 		w.Logger.Info("auto-top-up: funding app from bank",
 			"address", address, "fund_amount", fundAmount)
 
-		fundResult, err := w.Executor.FundApplication(address, netCfg.Bank, network, fundAmount, netCfg.RPCEndpoint)
+		fundResult, err := w.Executor.FundApplication(ctx, address, netCfg.Bank, network, fundAmount, netCfg.RPCEndpoint)
 		if err != nil || !fundResult.Success {
 			errMsg := "fund failed"
 			if err != nil {
@@ -159,14 +358,41 @@ func (w *Worker) processApp(ctx context.Context, network, address string, cfg mo
 			w.Logger.Error("auto-top-up: fund failed", "address", address, "error", errMsg)
 			event.Error = errMsg
 			w.addEvent(event)
-			return
+			return true
+		}
+		if fundResult.PendingID != "" {
+			event.Phase = "awaiting_signature"
+			event.PendingID = fundResult.PendingID
+			w.addEvent(event)
+			w.Logger.Info("auto-top-up: fund generated for offline signing",
+				"address", address, "pending_id", fundResult.PendingID)
+			return true
 		}
 		event.FundTxHash = fundResult.TxHash
 
 		// Poll for balance confirmation.
-		if !w.pollBalance(ctx, address, netCfg.APIEndpoint, app.LiquidBalance+fundAmount) {
+		if !w.pollBalance(ctx, address, netCfg.APIEndpoint, netCfg.BaseDenom(), required) {
 			w.Logger.Warn("auto-top-up: balance not confirmed after polling, proceeding anyway", "address", address)
 		}
+
+		// The stake may have changed while the fund confirmed.
+		app, err = w.Client.QueryApplication(ctx, address, netCfg.APIEndpoint, network, netCfg.BaseDenom())
+		if err != nil {
+			w.Logger.Error("auto-top-up: failed to query app before upstake", "address", address, "error", err)
+			event.Error = err.Error()
+			w.addEvent(event)
+			return true
+		}
+		if amountNeeded = cfg.TargetAmount - app.Stake; amountNeeded <= 0 {
+			w.Logger.Info("auto-top-up: stake reached target while funding, skipping upstake",
+				"address", address, "stake", app.Stake, "target", cfg.TargetAmount)
+			event.Phase = "complete"
+			event.Success = true
+			w.addEvent(event)
+			w.AppCache.Delete(network)
+			w.BankCache.Delete(network)
+			return true
+		}
 	} else {
 		w.Logger.Info("auto-top-up: app has sufficient liquid balance, skipping fund", "address", address)
 	}
@@ -176,7 +402,7 @@ func (w *Worker) processApp(ctx context.Context, network, address string, cfg mo
 	w.Logger.Info("auto-top-up: upstaking app",
 		"address", address, "amount", amountNeeded)
 
-	stakeResult, err := w.Executor.UpstakeApplication(address, netCfg.Bank, network, amountNeeded, netCfg.RPCEndpoint, netCfg.APIEndpoint)
+	stakeResult, err := w.Executor.UpstakeApplication(ctx, address, netCfg.Bank, network, amountNeeded, netCfg.RPCEndpoint, netCfg.APIEndpoint)
 	if err != nil || !stakeResult.Success {
 		errMsg := "upstake failed"
 		if err != nil {
@@ -187,7 +413,7 @@ func (w *Worker) processApp(ctx context.Context, network, address string, cfg mo
 		w.Logger.Error("auto-top-up: upstake failed", "address", address, "error", errMsg)
 		event.Error = errMsg
 		w.addEvent(event)
-		return
+		return true
 	}
 	event.StakeTxHash = stakeResult.TxHash
 
@@ -200,17 +426,52 @@ func (w *Worker) processApp(ctx context.Context, network, address string, cfg mo
 	w.BankCache.Delete(network)
 
 	w.Logger.Info("auto-top-up: success", "address", address, "network", network)
+	return true
+}
+
+// processSweep sends an app's liquid balance above its floor back to the bank.
+func (w *Worker) processSweep(ctx context.Context, network, address string, cfg models.SweepConfig, netCfg config.NetworkConfig) {
+	balance, err := w.Client.QueryBalance(ctx, address, netCfg.APIEndpoint, netCfg.BaseDenom())
+	if err != nil {
+		w.Logger.Error("sweep: failed to query balance", "address", address, "error", err)
+		w.addEvent(models.AutoTopUpEvent{
+			Timestamp: time.Now(),
+			Network:   network,
+			Address:   address,
+			Phase:     "sweep",
+			Error:     err.Error(),
+		})
+		return
+	}
+
+	amount := SweepAmount(balance, cfg.Floor)
+	if amount <= 0 {
+		w.Logger.Debug("sweep: liquid balance at or below floor, skipping",
+			"address", address, "balance", balance, "floor", cfg.Floor)
+		return
+	}
+
+	w.Logger.Info("sweep: returning excess liquid balance to bank",
+		"address", address, "balance", balance, "floor", cfg.Floor, "amount", amount)
+
+	result, err := w.Executor.SweepApplication(ctx, address, netCfg.Bank, network, amount, netCfg.RPCEndpoint)
+	w.RecordSweep(network, address, amount, result, err)
+
+	if err == nil && result.Success {
+		w.AppCache.Delete(network)
+		w.BankCache.Delete(network)
+	}
 }
 
-func (w *Worker) pollBalance(ctx context.Context, address, apiEndpoint string, minBalance int64) bool {
+func (w *Worker) pollBalance(ctx context.Context, address, apiEndpoint, denom string, minBalance int64) bool {
 	for i := 0; i < pollMaxAttempts; i++ {
 		select {
 		case <-ctx.Done():
 			w.Logger.Info("auto-top-up: poll cancelled during shutdown")
 			return false
-		case <-time.After(pollInterval):
+		case <-time.After(w.PollInterval):
 		}
-		balance, err := w.Client.QueryBalance(address, apiEndpoint)
+		balance, err := w.Client.QueryBalance(ctx, address, apiEndpoint, denom)
 		if err != nil {
 			w.Logger.Warn("auto-top-up: poll balance error", "attempt", i+1, "error", err)
 			continue
@@ -234,6 +495,37 @@ func (w *Worker) addEvent(event models.AutoTopUpEvent) {
 	}
 }
 
+// RecordSweep adds a sweep event for the outcome of a sweep transaction.
+// It is shared by the worker's sweep policy and on-demand sweeps.
+func (w *Worker) RecordSweep(network, address string, amount int64, result *models.TransactionResponse, err error) {
+	event := models.AutoTopUpEvent{
+		Timestamp:   time.Now(),
+		Network:     network,
+		Address:     address,
+		SweptAmount: amount,
+		Phase:       "sweep",
+	}
+
+	switch {
+	case err != nil:
+		event.Error = err.Error()
+	case !result.Success:
+		event.Error = "sweep failed"
+		if result.Message != "" {
+			event.Error = result.Message
+		}
+	default:
+		event.SweepTxHash = result.TxHash
+		event.Success = true
+	}
+
+	if event.Error != "" {
+		w.Logger.Error("sweep failed", "address", address, "network", network, "error", event.Error)
+	}
+
+	w.addEvent(event)
+}
+
 // Events returns a copy of recent events.
 func (w *Worker) Events() []models.AutoTopUpEvent {
 	w.eventsMu.Lock()
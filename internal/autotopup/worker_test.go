@@ -0,0 +1,257 @@
+package autotopup
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pokt-network/sam/internal/cache"
+	"github.com/pokt-network/sam/internal/config"
+	"github.com/pokt-network/sam/internal/models"
+	"github.com/pokt-network/sam/internal/pendingtx"
+	"github.com/pokt-network/sam/internal/pocket/fake"
+)
+
+const (
+	testApp  = "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	testBank = "pokt1bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+)
+
+// newFakeWorker returns a worker wired to an in-memory chain.
+func newFakeWorker(t *testing.T) (*Worker, *fake.Chain) {
+	t.Helper()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	cfg := &config.Config{}
+	cfg.Config.Networks = map[string]config.NetworkConfig{
+		"pocket": {
+			RPCEndpoint: "https://rpc.example.com",
+			APIEndpoint: "https://api.example.com",
+			Bank:        testBank,
+		},
+	}
+
+	dir := t.TempDir()
+	store, err := NewStore(filepath.Join(dir, "autotopup.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sweeps, err := NewSweepStore(filepath.Join(dir, "sweep.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pending, err := pendingtx.NewStore(filepath.Join(dir, "pending.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chain := fake.New()
+	w := NewWorker(store, sweeps, cfg, chain, chain, pending,
+		cache.New[models.ApplicationsResponse](time.Minute), cache.New[models.BankAccount](time.Minute), logger)
+	w.PollInterval = time.Millisecond
+	return w, chain
+}
+
+func TestWorker_FundPollUpstake(t *testing.T) {
+	w, chain := newFakeWorker(t)
+	chain.SetBalance(testBank, 10_000_000)
+	chain.SetApplication(testApp, "anvil", 500_000)
+
+	w.Store.Set("pocket", testApp, models.AutoTopUpConfig{Enabled: true, TriggerThreshold: 1_000_000, TargetAmount: 2_000_000})
+	w.RunOnce(context.Background())
+
+	if got := chain.Stake(testApp); got != 2_000_000 {
+		t.Errorf("stake = %d, want 2000000", got)
+	}
+	// Funded exactly the upstake plus its fee; the bank also paid the fund fee.
+	if got := chain.Balance(testApp); got != 0 {
+		t.Errorf("app liquid balance = %d, want 0", got)
+	}
+	if got := chain.Balance(testBank); got != 10_000_000-1_500_001-chain.Fee {
+		t.Errorf("bank balance = %d", got)
+	}
+
+	events := w.Events()
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+	ev := events[0]
+	if !ev.Success || ev.Phase != "complete" || ev.FundTxHash == "" || ev.StakeTxHash == "" {
+		t.Errorf("event = %+v, want completed fund and upstake", ev)
+	}
+}
+
+func TestWorker_SkipsFundWithLiquidBalance(t *testing.T) {
+	w, chain := newFakeWorker(t)
+	chain.SetBalance(testBank, 10_000_000)
+	chain.SetApplication(testApp, "anvil", 500_000)
+	chain.SetBalance(testApp, 2_000_000)
+
+	w.Store.Set("pocket", testApp, models.AutoTopUpConfig{Enabled: true, TriggerThreshold: 1_000_000, TargetAmount: 2_000_000})
+	w.RunOnce(context.Background())
+
+	for _, tx := range chain.Txs() {
+		if tx.Kind == "fund" {
+			t.Errorf("unexpected fund tx %+v", tx)
+		}
+	}
+	if got := chain.Stake(testApp); got != 2_000_000 {
+		t.Errorf("stake = %d, want 2000000", got)
+	}
+}
+
+func TestWorker_RequeriesBeforeTopUp(t *testing.T) {
+	w, chain := newFakeWorker(t)
+	chain.SetBalance(testBank, 10_000_000)
+	chain.SetApplication(testApp, "anvil", 2_000_000)
+
+	// The check saw a low stake, but a manual upstake landed since.
+	cfg := models.AutoTopUpConfig{Enabled: true, TriggerThreshold: 1_000_000, TargetAmount: 2_000_000}
+	checked := appCheck{app: &models.Application{Address: testApp, Stake: 500_000}}
+	if w.processApp(context.Background(), "pocket", testApp, cfg, w.Config.Config.Networks["pocket"], checked) {
+		t.Error("processApp() submitted a transaction for an app above its trigger")
+	}
+	if txs := chain.Txs(); len(txs) != 0 {
+		t.Errorf("got transactions %+v, want none", txs)
+	}
+
+	// Topped up from the fresh stake, not the checked one.
+	chain.SetApplication(testApp, "anvil", 800_000)
+	w.processApp(context.Background(), "pocket", testApp, cfg, w.Config.Config.Networks["pocket"], checked)
+	if got := chain.Stake(testApp); got != 2_000_000 {
+		t.Errorf("stake = %d, want 2000000", got)
+	}
+	if got := chain.Balance(testBank); got != 10_000_000-1_200_001-chain.Fee {
+		t.Errorf("bank balance = %d, want the fresh shortfall funded", got)
+	}
+}
+
+func TestWorker_SkipsFundWithUnknownBalance(t *testing.T) {
+	w, chain := newFakeWorker(t)
+	chain.SetBalance(testBank, 10_000_000)
+	chain.SetApplication(testApp, "anvil", 500_000)
+	chain.SetBalance(testApp, 2_000_000)
+	chain.FailBalance(testApp, "balance unavailable")
+
+	w.Store.Set("pocket", testApp, models.AutoTopUpConfig{Enabled: true, TriggerThreshold: 1_000_000, TargetAmount: 2_000_000})
+	w.RunOnce(context.Background())
+
+	if txs := chain.Txs(); len(txs) != 0 {
+		t.Errorf("got transactions %+v, want none while the balance is unknown", txs)
+	}
+	if events := w.Events(); len(events) != 1 || events[0].Error == "" {
+		t.Errorf("events = %+v, want one failed check", events)
+	}
+}
+
+func TestWorker_Wake(t *testing.T) {
+	w, chain := newFakeWorker(t)
+	chain.SetBalance(testBank, 10_000_000)
+	chain.SetApplication(testApp, "anvil", 500_000)
+	const other = "pokt1cccccccccccccccccccccccccccccccccccccc"
+	chain.SetApplication(other, "anvil", 500_000)
+
+	w.Store.Set("pocket", testApp, models.AutoTopUpConfig{Enabled: true, TriggerThreshold: 1_000_000, TargetAmount: 2_000_000})
+	w.Wake("pocket", testApp)
+	w.Wake("pocket", other) // no auto top-up config
+	w.CheckWoken(context.Background())
+
+	if got := chain.Stake(testApp); got != 2_000_000 {
+		t.Errorf("stake = %d, want 2000000", got)
+	}
+	if got := chain.Stake(other); got != 500_000 {
+		t.Errorf("unconfigured app stake = %d, want unchanged 500000", got)
+	}
+
+	// The events of the top-up itself wake the worker again; the cooldown
+	// keeps it from acting on them.
+	chain.Burn(testApp, 1_500_000)
+	txs := len(chain.Txs())
+	w.Wake("pocket", testApp)
+	w.CheckWoken(context.Background())
+	if got := len(chain.Txs()); got != txs {
+		t.Errorf("woken during cooldown: %d new txs", got-txs)
+	}
+
+	w.WakeCooldown = 0
+	w.Wake("pocket", testApp)
+	w.CheckWoken(context.Background())
+	if got := chain.Stake(testApp); got != 2_000_000 {
+		t.Errorf("stake after cooldown = %d, want 2000000", got)
+	}
+}
+
+func TestWorker_FundFailureRecorded(t *testing.T) {
+	w, chain := newFakeWorker(t)
+	chain.SetApplication(testApp, "anvil", 500_000)
+	// The bank is empty, so the fund fails and no upstake is attempted.
+
+	w.Store.Set("pocket", testApp, models.AutoTopUpConfig{Enabled: true, TriggerThreshold: 1_000_000, TargetAmount: 2_000_000})
+	w.RunOnce(context.Background())
+
+	if got := chain.Stake(testApp); got != 500_000 {
+		t.Errorf("stake = %d, want unchanged 500000", got)
+	}
+	events := w.Events()
+	if len(events) != 1 || events[0].Phase != "fund" || events[0].Error == "" {
+		t.Fatalf("events = %+v, want one failed fund event", events)
+	}
+}
+
+func TestWorker_TopUpOverflowRecorded(t *testing.T) {
+	w, chain := newFakeWorker(t)
+	chain.SetBalance(testBank, 10_000_000)
+	chain.SetApplication(testApp, "anvil", 0)
+
+	// The target plus the upstake fee doesn't fit in an int64.
+	w.Store.Set("pocket", testApp, models.AutoTopUpConfig{Enabled: true, TriggerThreshold: 1_000_000, TargetAmount: math.MaxInt64})
+	w.RunOnce(context.Background())
+
+	if txs := chain.Txs(); len(txs) != 0 {
+		t.Errorf("unexpected txs %+v", txs)
+	}
+	events := w.Events()
+	if len(events) != 1 || events[0].Error == "" {
+		t.Fatalf("events = %+v, want one failed event", events)
+	}
+}
+
+func TestWorker_FeeGrantDropsFeeBuffer(t *testing.T) {
+	w, chain := newFakeWorker(t)
+	chain.SetBalance(testBank, 10_000_000)
+	chain.SetApplication(testApp, "anvil", 500_000)
+	chain.GrantFeeAllowance(context.Background(), testApp, testBank, "pocket", 0, 0, "")
+
+	w.Store.Set("pocket", testApp, models.AutoTopUpConfig{Enabled: true, TriggerThreshold: 1_000_000, TargetAmount: 2_000_000})
+	w.RunOnce(context.Background())
+
+	for _, tx := range chain.Txs() {
+		if tx.Kind == "fund" && tx.Amount != 1_500_000 {
+			t.Errorf("fund amount = %d, want 1500000 without fee buffer", tx.Amount)
+		}
+	}
+	if got := chain.Stake(testApp); got != 2_000_000 {
+		t.Errorf("stake = %d, want 2000000", got)
+	}
+}
+
+func TestWorker_SweepPolicy(t *testing.T) {
+	w, chain := newFakeWorker(t)
+	chain.SetApplication(testApp, "anvil", 5_000_000)
+	chain.SetBalance(testApp, 3_000_000)
+
+	w.Sweeps.Set("pocket", testApp, models.SweepConfig{Enabled: true, Floor: 1_000_000})
+	w.RunOnce(context.Background())
+
+	if got := chain.Balance(testApp); got != 1_000_000 {
+		t.Errorf("app balance after sweep = %d, want floor 1000000", got)
+	}
+	if got := chain.Balance(testBank); got != 2_000_000-SweepFeeReserve {
+		t.Errorf("bank balance after sweep = %d", got)
+	}
+}
@@ -10,10 +10,13 @@ type entry[T any] struct {
 	timestamp time.Time
 }
 
-// Cache is a generic thread-safe TTL cache keyed by string.
+// Cache is a generic thread-safe TTL cache keyed by string. Each key has a
+// generation that Delete bumps, so a load started before an invalidation
+// can be dropped instead of overwriting it (see SetIfCurrent).
 type Cache[T any] struct {
 	mu       sync.RWMutex
 	items    map[string]entry[T]
+	gens     map[string]uint64
 	duration time.Duration
 }
 
@@ -21,6 +24,7 @@ type Cache[T any] struct {
 func New[T any](duration time.Duration) *Cache[T] {
 	return &Cache[T]{
 		items:    make(map[string]entry[T]),
+		gens:     make(map[string]uint64),
 		duration: duration,
 	}
 }
@@ -38,6 +42,21 @@ func (c *Cache[T]) Get(key string) (T, bool) {
 	return e.value, true
 }
 
+// GetStale returns the cached value even if it has expired, whether it has
+// expired, and true if the key exists. It lets callers serve stale data
+// while refreshing it.
+func (c *Cache[T]) GetStale(key string) (value T, expired bool, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		var zero T
+		return zero, false, false
+	}
+	return e.value, time.Since(e.timestamp) >= c.duration, true
+}
+
 // Set stores a value under the given key with the current timestamp.
 func (c *Cache[T]) Set(key string, value T) {
 	c.mu.Lock()
@@ -46,10 +65,33 @@ func (c *Cache[T]) Set(key string, value T) {
 	c.items[key] = entry[T]{value: value, timestamp: time.Now()}
 }
 
-// Delete removes a key from the cache.
+// SetIfCurrent stores a value like Set if key is still at generation gen,
+// and reports whether it did. A load reads Generation before it starts and
+// stores its result with SetIfCurrent, so a Delete in between wins.
+func (c *Cache[T]) SetIfCurrent(key string, value T, gen uint64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.gens[key] != gen {
+		return false
+	}
+	c.items[key] = entry[T]{value: value, timestamp: time.Now()}
+	return true
+}
+
+// Generation returns the number of times key has been deleted.
+func (c *Cache[T]) Generation(key string) uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.gens[key]
+}
+
+// Delete removes a key from the cache and bumps its generation.
 func (c *Cache[T]) Delete(key string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	delete(c.items, key)
+	c.gens[key]++
 }
@@ -0,0 +1,92 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCache_GetStale(t *testing.T) {
+	c := New[int](10 * time.Millisecond)
+	c.Set("k", 1)
+
+	if v, expired, ok := c.GetStale("k"); !ok || expired || v != 1 {
+		t.Errorf("GetStale() = %d, %v, %v; want 1, false, true", v, expired, ok)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if _, ok := c.Get("k"); ok {
+		t.Error("Get() returned an expired value")
+	}
+	if v, expired, ok := c.GetStale("k"); !ok || !expired || v != 1 {
+		t.Errorf("GetStale() = %d, %v, %v; want 1, true, true", v, expired, ok)
+	}
+
+	if _, _, ok := c.GetStale("missing"); ok {
+		t.Error("GetStale() found a missing key")
+	}
+}
+
+func TestCache_SetIfCurrent(t *testing.T) {
+	c := New[int](time.Minute)
+	gen := c.Generation("k")
+
+	// An invalidation after the load started drops its result.
+	c.Delete("k")
+	if c.SetIfCurrent("k", 1, gen) {
+		t.Error("SetIfCurrent() stored a value from before Delete")
+	}
+	if _, ok := c.Get("k"); ok {
+		t.Error("Get() found a value dropped by SetIfCurrent")
+	}
+
+	gen = c.Generation("k")
+	if !c.SetIfCurrent("k", 2, gen) {
+		t.Error("SetIfCurrent() dropped a current value")
+	}
+	if v, ok := c.Get("k"); !ok || v != 2 {
+		t.Errorf("Get() = %d, %v; want 2, true", v, ok)
+	}
+}
+
+func TestFlight_Do(t *testing.T) {
+	var f Flight[int]
+	var calls atomic.Int32
+	release := make(chan struct{})
+
+	var wg sync.WaitGroup
+	results := make([]int, 5)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _, _ = f.Do("k", func() (int, error) {
+				calls.Add(1)
+				<-release
+				return 42, nil
+			})
+		}()
+	}
+
+	// Let every caller join the in-flight call before it finishes.
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("fn ran %d times, want 1", got)
+	}
+	for i, v := range results {
+		if v != 42 {
+			t.Errorf("results[%d] = %d, want 42", i, v)
+		}
+	}
+
+	// Once done, the next call runs again and errors are passed through.
+	wantErr := errors.New("boom")
+	if _, err, shared := f.Do("k", func() (int, error) { return 0, wantErr }); err != wantErr || shared {
+		t.Errorf("Do() = %v, shared %v; want %v, false", err, shared, wantErr)
+	}
+}
@@ -0,0 +1,46 @@
+package cache
+
+import "sync"
+
+// call is an in-flight or completed Flight.Do call.
+type call[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// Flight deduplicates concurrent loads: callers of Do with the same key
+// while a load is running wait for it and share its result. The zero value
+// is ready to use.
+type Flight[T any] struct {
+	mu    sync.Mutex
+	calls map[string]*call[T]
+}
+
+// Do runs fn for key unless a call for key is already in flight, in which
+// case it waits for that call and returns its result. shared reports
+// whether the result came from another caller's fn.
+func (f *Flight[T]) Do(key string, fn func() (T, error)) (value T, err error, shared bool) {
+	f.mu.Lock()
+	if f.calls == nil {
+		f.calls = make(map[string]*call[T])
+	}
+	if c, ok := f.calls[key]; ok {
+		f.mu.Unlock()
+		<-c.done
+		return c.value, c.err, true
+	}
+	c := &call[T]{done: make(chan struct{})}
+	f.calls[key] = c
+	f.mu.Unlock()
+
+	defer func() {
+		f.mu.Lock()
+		delete(f.calls, key)
+		f.mu.Unlock()
+		close(c.done)
+	}()
+
+	c.value, c.err = fn()
+	return c.value, c.err, false
+}
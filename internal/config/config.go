@@ -3,32 +3,171 @@ package config
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/pokt-network/sam/internal/models"
 	"github.com/pokt-network/sam/internal/validate"
 )
 
 var configMu sync.Mutex
 
+var envName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Bank signing modes.
+const (
+	BankSigningHot      = "hot"
+	BankSigningOffline  = "offline"
+	BankSigningMultisig = "multisig"
+)
+
+// Signer backends.
+const (
+	SignerKeyring = "keyring"
+	SignerRemote  = "remote"
+)
+
+// SignerConfig describes a named transaction signer.
+type SignerConfig struct {
+	Type     string `yaml:"type"`      // "keyring" or "remote"
+	Endpoint string `yaml:"endpoint"`  // remote: URL that signs sign-bytes
+	TokenEnv string `yaml:"token_env"` // remote: env var holding a bearer token
+}
+
+// RetryConfig controls retries of idempotent REST queries. Zero values use
+// the defaults.
+type RetryConfig struct {
+	Attempts       int           `yaml:"attempts"`        // total tries per query; default 3
+	InitialBackoff time.Duration `yaml:"initial-backoff"` // default 200ms, doubled per retry
+	MaxBackoff     time.Duration `yaml:"max-backoff"`     // default 2s
+}
+
+// CircuitBreakerConfig controls the per-endpoint circuit breaker. Zero
+// values use the defaults.
+type CircuitBreakerConfig struct {
+	Failures int           `yaml:"failures"` // consecutive failures that open the circuit; default 5
+	Cooldown time.Duration `yaml:"cooldown"` // how long it stays open; default 30s
+}
+
+// HistoryConfig controls the stake and balance history. Zero values use
+// the defaults.
+type HistoryConfig struct {
+	Resolution           time.Duration `yaml:"resolution"`            // minimum spacing of recorded points; default 5m
+	Retention            time.Duration `yaml:"retention"`             // how long points are kept; default 720h (30 days)
+	DownsampleAfter      time.Duration `yaml:"downsample-after"`      // age at which points are thinned; default 24h
+	DownsampleResolution time.Duration `yaml:"downsample-resolution"` // spacing of thinned points; default 1h
+}
+
+// LimitsConfig bounds outbound REST queries for a network. Zero values use
+// the defaults.
+type LimitsConfig struct {
+	RequestsPerSecond float64 `yaml:"requests_per_second"` // per endpoint; default 10
+	Burst             int     `yaml:"burst"`               // per endpoint; default 20
+	Concurrency       int     `yaml:"concurrency"`         // requests in flight per network; default 8
+}
+
+// MultisigConfig describes a multisig bank account.
+type MultisigConfig struct {
+	Key       string   `yaml:"key"`       // keyring name of the multisig public key
+	Threshold int      `yaml:"threshold"` // signatures required
+	Signers   []string `yaml:"signers"`   // member addresses
+}
+
 // NetworkConfig holds per-network connection and address settings.
 type NetworkConfig struct {
-	RPCEndpoint  string   `yaml:"rpc_endpoint"`
-	APIEndpoint  string   `yaml:"api_endpoint"`
-	Gateways     []string `yaml:"gateways"`
-	Bank         string   `yaml:"bank"`
-	Applications []string `yaml:"applications"`
+	RPCEndpoint  string            `yaml:"rpc_endpoint"`
+	APIEndpoint  string            `yaml:"api_endpoint"`
+	RPCEndpoints []string          `yaml:"rpc_endpoints"` // fallbacks, tried in order after rpc_endpoint
+	APIEndpoints []string          `yaml:"api_endpoints"` // fallbacks, tried in order after api_endpoint
+	Limits       LimitsConfig      `yaml:"limits"`
+	Denom        string            `yaml:"denom"` // base denom of stakes, balances and fees; "upokt" when empty
+	Gateways     []string          `yaml:"gateways"`
+	Subscribe    bool              `yaml:"subscribe"` // watch the RPC websocket for events touching tracked addresses
+	Bank         string            `yaml:"bank"`
+	BankSigning  string            `yaml:"bank_signing"` // "hot" (default), "offline" or "multisig"
+	Multisig     MultisigConfig    `yaml:"multisig"`
+	Signer       string            `yaml:"signer"`      // default signer name; "keyring" when empty
+	KeySigners   map[string]string `yaml:"key_signers"` // address -> signer name
+	Applications []string          `yaml:"applications"`
+}
+
+// BaseDenom returns the network's base denomination, upokt by default.
+func (n NetworkConfig) BaseDenom() string {
+	if n.Denom != "" {
+		return n.Denom
+	}
+	return models.Denom
+}
+
+// OfflineBank reports whether bank transactions must be signed outside SAM.
+// A multisig bank is always signed outside SAM.
+func (n NetworkConfig) OfflineBank() bool {
+	return n.BankSigning == BankSigningOffline || n.BankSigning == BankSigningMultisig
+}
+
+// MultisigBank reports whether the bank is a multisig account.
+func (n NetworkConfig) MultisigBank() bool {
+	return n.BankSigning == BankSigningMultisig
+}
+
+// SignerFor returns the signer name for a key address: its key_signers
+// entry, else the network signer, else the local keyring.
+func (n NetworkConfig) SignerFor(address string) string {
+	if name, ok := n.KeySigners[address]; ok && name != "" {
+		return name
+	}
+	if n.Signer != "" {
+		return n.Signer
+	}
+	return SignerKeyring
+}
+
+// RPCEndpointList returns rpc_endpoint followed by the rpc_endpoints
+// fallbacks, without duplicates.
+func (n NetworkConfig) RPCEndpointList() []string {
+	return endpointList(n.RPCEndpoint, n.RPCEndpoints)
+}
+
+// APIEndpointList returns api_endpoint followed by the api_endpoints
+// fallbacks, without duplicates.
+func (n NetworkConfig) APIEndpointList() []string {
+	return endpointList(n.APIEndpoint, n.APIEndpoints)
+}
+
+func endpointList(primary string, fallbacks []string) []string {
+	list := make([]string, 0, 1+len(fallbacks))
+	seen := make(map[string]bool, 1+len(fallbacks))
+	for _, ep := range append([]string{primary}, fallbacks...) {
+		if ep == "" || seen[ep] {
+			continue
+		}
+		seen[ep] = true
+		list = append(list, ep)
+	}
+	return list
 }
 
 // Config is the top-level configuration loaded from config.yaml.
 type Config struct {
 	Config struct {
-		KeyringBackend string                   `yaml:"keyring-backend"`
-		PocketdHome    string                   `yaml:"pocketd-home"`
-		Thresholds     Thresholds               `yaml:"thresholds"`
-		Networks       map[string]NetworkConfig `yaml:"networks"`
+		KeyringBackend        string                   `yaml:"keyring-backend"`
+		KeyringPassphraseFile string                   `yaml:"keyring-passphrase-file"` // secrets file holding the keyring passphrase
+		KeyringPassphraseEnv  string                   `yaml:"keyring-passphrase-env"`  // or: env var holding it
+		PocketdHome           string                   `yaml:"pocketd-home"`
+		PocketdTimeout        time.Duration            `yaml:"pocketd-timeout"` // per pocketd command; default 2m
+		PendingTTL            time.Duration            `yaml:"pending-ttl"`     // unsigned transactions expire after it; default 24h
+		QueryTimeout          time.Duration            `yaml:"query-timeout"`   // per REST query; default 10s
+		QueryRetry            RetryConfig              `yaml:"query-retry"`
+		CircuitBreaker        CircuitBreakerConfig     `yaml:"circuit-breaker"`
+		HeightStallTimeout    time.Duration            `yaml:"height-stall-timeout"` // endpoint unhealthy when its height doesn't advance; default 5m
+		History               HistoryConfig            `yaml:"history"`
+		Thresholds            Thresholds               `yaml:"thresholds"`
+		Signers               map[string]SignerConfig  `yaml:"signers"`
+		Networks              map[string]NetworkConfig `yaml:"networks"`
 	} `yaml:"config"`
 }
 
@@ -50,15 +189,26 @@ func Load(path string) (*Config, error) {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
 
-	// Set default API endpoints if not provided.
 	for name, network := range cfg.Config.Networks {
+		// A network may list only fallbacks; the first one becomes primary.
+		if network.RPCEndpoint == "" && len(network.RPCEndpoints) > 0 {
+			network.RPCEndpoint = network.RPCEndpoints[0]
+		}
+		if network.APIEndpoint == "" && len(network.APIEndpoints) > 0 {
+			network.APIEndpoint = network.APIEndpoints[0]
+		}
+
+		// Set default API endpoints if not provided, one per RPC endpoint.
 		if network.APIEndpoint == "" {
-			apiEndpoint := strings.Replace(network.RPCEndpoint, ":443", "", 1)
-			apiEndpoint = strings.Replace(apiEndpoint, ":26657", "", 1)
-			apiEndpoint = strings.Replace(apiEndpoint, "rpc", "api", 1)
-			network.APIEndpoint = apiEndpoint
-			cfg.Config.Networks[name] = network
+			for i, rpc := range network.RPCEndpointList() {
+				if i == 0 {
+					network.APIEndpoint = defaultAPIEndpoint(rpc)
+				} else {
+					network.APIEndpoints = append(network.APIEndpoints, defaultAPIEndpoint(rpc))
+				}
+			}
 		}
+		cfg.Config.Networks[name] = network
 	}
 
 	if err := validateConfig(&cfg); err != nil {
@@ -68,6 +218,14 @@ func Load(path string) (*Config, error) {
 	return &cfg, nil
 }
 
+// defaultAPIEndpoint derives a REST endpoint from an RPC endpoint, following
+// the rpc/api naming of the public endpoints.
+func defaultAPIEndpoint(rpcEndpoint string) string {
+	apiEndpoint := strings.Replace(rpcEndpoint, ":443", "", 1)
+	apiEndpoint = strings.Replace(apiEndpoint, ":26657", "", 1)
+	return strings.Replace(apiEndpoint, "rpc", "api", 1)
+}
+
 // AddApplicationAddress adds an application address to the in-memory config.
 // Returns an error if the address already exists in the network.
 func (c *Config) AddApplicationAddress(network, address string) error {
@@ -230,6 +388,44 @@ func validateConfig(cfg *Config) error {
 		}
 	}
 
+	if cfg.Config.PocketdTimeout < 0 {
+		return fmt.Errorf("pocketd-timeout must not be negative")
+	}
+	if cfg.Config.QueryTimeout < 0 {
+		return fmt.Errorf("query-timeout must not be negative")
+	}
+	if cfg.Config.PendingTTL < 0 {
+		return fmt.Errorf("pending-ttl must not be negative")
+	}
+	if r := cfg.Config.QueryRetry; r.Attempts < 0 || r.InitialBackoff < 0 || r.MaxBackoff < 0 {
+		return fmt.Errorf("query-retry: values must not be negative")
+	}
+	if r := cfg.Config.QueryRetry; r.InitialBackoff > 0 && r.MaxBackoff > 0 && r.MaxBackoff < r.InitialBackoff {
+		return fmt.Errorf("query-retry: max-backoff must not be less than initial-backoff")
+	}
+	if cb := cfg.Config.CircuitBreaker; cb.Failures < 0 || cb.Cooldown < 0 {
+		return fmt.Errorf("circuit-breaker: values must not be negative")
+	}
+	if cfg.Config.HeightStallTimeout < 0 {
+		return fmt.Errorf("height-stall-timeout must not be negative")
+	}
+	if h := cfg.Config.History; h.Resolution < 0 || h.Retention < 0 || h.DownsampleAfter < 0 || h.DownsampleResolution < 0 {
+		return fmt.Errorf("history: values must not be negative")
+	}
+
+	if cfg.Config.KeyringPassphraseFile != "" && cfg.Config.KeyringPassphraseEnv != "" {
+		return fmt.Errorf("set only one of keyring-passphrase-file and keyring-passphrase-env")
+	}
+	if cfg.Config.KeyringPassphraseEnv != "" && !envName.MatchString(cfg.Config.KeyringPassphraseEnv) {
+		return fmt.Errorf("keyring-passphrase-env: invalid environment variable name %q", cfg.Config.KeyringPassphraseEnv)
+	}
+
+	for name, signer := range cfg.Config.Signers {
+		if err := validateSigner(name, signer); err != nil {
+			return fmt.Errorf("signer %q: %w", name, err)
+		}
+	}
+
 	for name, network := range cfg.Config.Networks {
 		if err := validate.Endpoint(network.RPCEndpoint); err != nil {
 			return fmt.Errorf("network %q rpc_endpoint: %w", name, err)
@@ -237,11 +433,49 @@ func validateConfig(cfg *Config) error {
 		if err := validate.Endpoint(network.APIEndpoint); err != nil {
 			return fmt.Errorf("network %q api_endpoint: %w", name, err)
 		}
+		for i, ep := range network.RPCEndpoints {
+			if err := validate.Endpoint(ep); err != nil {
+				return fmt.Errorf("network %q rpc_endpoints[%d]: %w", name, i, err)
+			}
+		}
+		for i, ep := range network.APIEndpoints {
+			if err := validate.Endpoint(ep); err != nil {
+				return fmt.Errorf("network %q api_endpoints[%d]: %w", name, i, err)
+			}
+		}
+		if network.Denom != "" {
+			if err := validate.Denom(network.Denom); err != nil {
+				return fmt.Errorf("network %q denom: %w", name, err)
+			}
+		}
+		if l := network.Limits; l.RequestsPerSecond < 0 || l.Burst < 0 || l.Concurrency < 0 {
+			return fmt.Errorf("network %q limits: values must not be negative", name)
+		}
 		if network.Bank != "" {
 			if err := validate.Address(network.Bank); err != nil {
 				return fmt.Errorf("network %q bank address: %w", name, err)
 			}
 		}
+		switch network.BankSigning {
+		case "", BankSigningHot, BankSigningOffline:
+		case BankSigningMultisig:
+			if err := validateMultisig(network.Multisig); err != nil {
+				return fmt.Errorf("network %q multisig: %w", name, err)
+			}
+		default:
+			return fmt.Errorf("network %q bank_signing: must be %q, %q or %q, got %q", name, BankSigningHot, BankSigningOffline, BankSigningMultisig, network.BankSigning)
+		}
+		if network.Signer != "" && !cfg.hasSigner(network.Signer) {
+			return fmt.Errorf("network %q signer: unknown signer %q", name, network.Signer)
+		}
+		for addr, signer := range network.KeySigners {
+			if err := validate.Address(addr); err != nil {
+				return fmt.Errorf("network %q key_signers: %w", name, err)
+			}
+			if !cfg.hasSigner(signer) {
+				return fmt.Errorf("network %q key_signers[%s]: unknown signer %q", name, addr, signer)
+			}
+		}
 		for i, addr := range network.Applications {
 			if err := validate.Address(addr); err != nil {
 				return fmt.Errorf("network %q application[%d]: %w", name, i, err)
@@ -256,3 +490,51 @@ func validateConfig(cfg *Config) error {
 
 	return nil
 }
+
+// hasSigner reports whether name is the built-in keyring or a configured signer.
+func (c *Config) hasSigner(name string) bool {
+	if name == SignerKeyring {
+		return true
+	}
+	_, ok := c.Config.Signers[name]
+	return ok
+}
+
+func validateSigner(name string, s SignerConfig) error {
+	if err := validate.KeyName(name); err != nil {
+		return fmt.Errorf("name: %w", err)
+	}
+	switch s.Type {
+	case SignerKeyring:
+	case SignerRemote:
+		if err := validate.Endpoint(s.Endpoint); err != nil {
+			return fmt.Errorf("endpoint: %w", err)
+		}
+		if s.TokenEnv != "" && !envName.MatchString(s.TokenEnv) {
+			return fmt.Errorf("token_env: invalid environment variable name %q", s.TokenEnv)
+		}
+	default:
+		return fmt.Errorf("type: must be %q or %q, got %q", SignerKeyring, SignerRemote, s.Type)
+	}
+	return nil
+}
+
+func validateMultisig(m MultisigConfig) error {
+	if err := validate.KeyName(m.Key); err != nil {
+		return fmt.Errorf("key: %w", err)
+	}
+	if m.Threshold < 1 || m.Threshold > len(m.Signers) {
+		return fmt.Errorf("threshold must be between 1 and the number of signers (%d), got %d", len(m.Signers), m.Threshold)
+	}
+	seen := make(map[string]bool, len(m.Signers))
+	for i, addr := range m.Signers {
+		if err := validate.Address(addr); err != nil {
+			return fmt.Errorf("signer[%d]: %w", i, err)
+		}
+		if seen[addr] {
+			return fmt.Errorf("signer[%d]: duplicate address %s", i, addr)
+		}
+		seen[addr] = true
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func makeTestConfig() *Config {
@@ -387,3 +388,374 @@ func TestLoad_NoNetworks(t *testing.T) {
 		t.Fatal("expected validation error for no networks")
 	}
 }
+
+func TestLoad_BankSigning(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        string
+		wantErr     bool
+		wantOffline bool
+	}{
+		{"default", "", false, false},
+		{"hot", "hot", false, false},
+		{"offline", "offline", false, true},
+		{"unknown", "cold", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configContent := `config:
+  networks:
+    pocket:
+      rpc_endpoint: https://rpc.example.com
+      api_endpoint: https://api.example.com
+      bank: pokt1bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb
+      bank_signing: "` + tt.mode + `"
+`
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			os.WriteFile(path, []byte(configContent), 0600)
+
+			cfg, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := cfg.Config.Networks["pocket"].OfflineBank(); got != tt.wantOffline {
+				t.Errorf("OfflineBank() = %v, want %v", got, tt.wantOffline)
+			}
+		})
+	}
+}
+
+func TestLoad_MultisigBank(t *testing.T) {
+	tests := []struct {
+		name     string
+		multisig string
+		wantErr  bool
+	}{
+		{
+			name: "valid",
+			multisig: `
+        key: bank-multisig
+        threshold: 2
+        signers:
+          - pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa
+          - pokt1cccccccccccccccccccccccccccccccccccccc`,
+			wantErr: false,
+		},
+		{
+			name: "threshold above signers",
+			multisig: `
+        key: bank-multisig
+        threshold: 3
+        signers:
+          - pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa
+          - pokt1cccccccccccccccccccccccccccccccccccccc`,
+			wantErr: true,
+		},
+		{
+			name: "missing key",
+			multisig: `
+        threshold: 1
+        signers:
+          - pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa`,
+			wantErr: true,
+		},
+		{
+			name: "duplicate signer",
+			multisig: `
+        key: bank-multisig
+        threshold: 1
+        signers:
+          - pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa
+          - pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configContent := `config:
+  networks:
+    pocket:
+      rpc_endpoint: https://rpc.example.com
+      api_endpoint: https://api.example.com
+      bank: pokt1bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb
+      bank_signing: multisig
+      multisig:` + tt.multisig + `
+`
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			os.WriteFile(path, []byte(configContent), 0600)
+
+			cfg, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !cfg.Config.Networks["pocket"].OfflineBank() {
+				t.Error("multisig bank should be signed offline")
+			}
+		})
+	}
+}
+
+func TestLoad_Signers(t *testing.T) {
+	tests := []struct {
+		name    string
+		signers string
+		network string
+		wantErr bool
+	}{
+		{
+			name: "remote signer per network and key",
+			signers: `
+    vault:
+      type: remote
+      endpoint: https://signer.example.com/sign
+      token_env: SAM_SIGNER_TOKEN`,
+			network: `
+      signer: vault
+      key_signers:
+        pokt1bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb: keyring`,
+			wantErr: false,
+		},
+		{
+			name: "unknown network signer",
+			network: `
+      signer: vault`,
+			wantErr: true,
+		},
+		{
+			name: "unknown key signer",
+			network: `
+      key_signers:
+        pokt1bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb: vault`,
+			wantErr: true,
+		},
+		{
+			name: "remote signer without endpoint",
+			signers: `
+    vault:
+      type: remote`,
+			wantErr: true,
+		},
+		{
+			name: "unknown signer type",
+			signers: `
+    vault:
+      type: hsm`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configContent := `config:
+  signers:` + tt.signers + `
+  networks:
+    pocket:
+      rpc_endpoint: https://rpc.example.com
+      api_endpoint: https://api.example.com` + tt.network + `
+`
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			os.WriteFile(path, []byte(configContent), 0600)
+
+			_, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNetworkConfig_SignerFor(t *testing.T) {
+	n := NetworkConfig{
+		Signer:     "vault",
+		KeySigners: map[string]string{"pokt1bank": "keyring"},
+	}
+	if got := n.SignerFor("pokt1bank"); got != "keyring" {
+		t.Errorf("SignerFor(bank) = %q, want keyring", got)
+	}
+	if got := n.SignerFor("pokt1app"); got != "vault" {
+		t.Errorf("SignerFor(app) = %q, want vault", got)
+	}
+	if got := (NetworkConfig{}).SignerFor("pokt1app"); got != SignerKeyring {
+		t.Errorf("SignerFor() default = %q, want %q", got, SignerKeyring)
+	}
+}
+
+func TestLoad_KeyringPassphrase(t *testing.T) {
+	tests := []struct {
+		name    string
+		lines   string
+		wantErr bool
+	}{
+		{"file", "  keyring-passphrase-file: /run/secrets/keyring\n", false},
+		{"env", "  keyring-passphrase-env: SAM_KEYRING_PASSPHRASE\n", false},
+		{"both", "  keyring-passphrase-file: /run/secrets/keyring\n  keyring-passphrase-env: SAM_KEYRING_PASSPHRASE\n", true},
+		{"invalid env name", "  keyring-passphrase-env: \"SAM-KEYRING\"\n", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configContent := `config:
+  keyring-backend: file
+` + tt.lines + `  networks:
+    pocket:
+      rpc_endpoint: https://rpc.example.com
+      api_endpoint: https://api.example.com
+`
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			os.WriteFile(path, []byte(configContent), 0600)
+
+			_, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoad_Timeouts(t *testing.T) {
+	tests := []struct {
+		name    string
+		lines   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"unset", "", 0, false},
+		{"set", "  pocketd-timeout: 90s\n  query-timeout: 5s\n", 90 * time.Second, false},
+		{"negative pocketd", "  pocketd-timeout: -1s\n", 0, true},
+		{"negative query", "  query-timeout: -1s\n", 0, true},
+		{"retry", "  query-retry:\n    attempts: 5\n    initial-backoff: 100ms\n    max-backoff: 1s\n", 0, false},
+		{"negative retry", "  query-retry:\n    attempts: -1\n", 0, true},
+		{"inverted backoff", "  query-retry:\n    initial-backoff: 2s\n    max-backoff: 1s\n", 0, true},
+		{"circuit breaker", "  circuit-breaker:\n    failures: 3\n    cooldown: 1m\n", 0, false},
+		{"negative cooldown", "  circuit-breaker:\n    cooldown: -1m\n", 0, true},
+		{"history", "  history:\n    resolution: 10m\n    retention: 2160h\n", 0, false},
+		{"negative history", "  history:\n    downsample-after: -1h\n", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configContent := `config:
+  keyring-backend: test
+` + tt.lines + `  networks:
+    pocket:
+      rpc_endpoint: https://rpc.example.com
+      api_endpoint: https://api.example.com
+`
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			os.WriteFile(path, []byte(configContent), 0600)
+
+			cfg, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && cfg.Config.PocketdTimeout != tt.want {
+				t.Errorf("PocketdTimeout = %v, want %v", cfg.Config.PocketdTimeout, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_EndpointLists(t *testing.T) {
+	configContent := `config:
+  keyring-backend: test
+  networks:
+    pocket:
+      rpc_endpoints:
+        - https://rpc.one.example.com
+        - https://rpc.two.example.com
+    beta:
+      rpc_endpoint: https://rpc.beta.example.com
+      api_endpoint: https://api.beta.example.com
+      api_endpoints:
+        - https://api.beta.example.com
+        - https://api.beta-backup.example.com
+`
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(path, []byte(configContent), 0600)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	pocket := cfg.Config.Networks["pocket"]
+	if pocket.RPCEndpoint != "https://rpc.one.example.com" {
+		t.Errorf("rpc_endpoint = %q, want first of rpc_endpoints", pocket.RPCEndpoint)
+	}
+	wantAPI := []string{"https://api.one.example.com", "https://api.two.example.com"}
+	if got := pocket.APIEndpointList(); strings.Join(got, ",") != strings.Join(wantAPI, ",") {
+		t.Errorf("derived API endpoints = %v, want %v", got, wantAPI)
+	}
+
+	beta := cfg.Config.Networks["beta"]
+	if got := beta.APIEndpointList(); len(got) != 2 || got[1] != "https://api.beta-backup.example.com" {
+		t.Errorf("beta API endpoints = %v, want primary then backup without duplicates", got)
+	}
+}
+
+func TestLoad_InvalidFallbackEndpoint(t *testing.T) {
+	configContent := `config:
+  networks:
+    pocket:
+      rpc_endpoint: https://rpc.example.com
+      rpc_endpoints:
+        - ftp://rpc.example.com
+`
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(path, []byte(configContent), 0600)
+
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "rpc_endpoints[0]") {
+		t.Errorf("Load() error = %v, want rpc_endpoints[0] error", err)
+	}
+}
+
+func TestLoad_NegativeLimits(t *testing.T) {
+	configContent := `config:
+  networks:
+    pocket:
+      rpc_endpoint: https://rpc.example.com
+      limits:
+        requests_per_second: 5
+        concurrency: -1
+`
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(path, []byte(configContent), 0600)
+
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "limits") {
+		t.Errorf("Load() error = %v, want limits error", err)
+	}
+}
+
+func TestLoad_Denom(t *testing.T) {
+	configContent := `config:
+  networks:
+    pocket:
+      rpc_endpoint: https://rpc.example.com
+    local:
+      rpc_endpoint: https://rpc.local.example.com
+      denom: ustake
+`
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(path, []byte(configContent), 0600)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := cfg.Config.Networks["pocket"].BaseDenom(); got != "upokt" {
+		t.Errorf("default BaseDenom() = %q, want upokt", got)
+	}
+	if got := cfg.Config.Networks["local"].BaseDenom(); got != "ustake" {
+		t.Errorf("BaseDenom() = %q, want ustake", got)
+	}
+
+	os.WriteFile(path, []byte(strings.Replace(configContent, "ustake", "1stake --fees", 1)), 0600)
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "denom") {
+		t.Errorf("Load() error = %v, want denom error", err)
+	}
+}
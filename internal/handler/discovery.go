@@ -0,0 +1,261 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/pokt-network/sam/internal/config"
+	"github.com/pokt-network/sam/internal/models"
+	"github.com/pokt-network/sam/internal/pocket"
+	"github.com/pokt-network/sam/internal/validate"
+)
+
+// DefaultDiscoveryInterval is how often RunDiscovery compares the apps
+// delegated to each network's gateways with its config.
+const DefaultDiscoveryInterval = 10 * time.Minute
+
+// maxAdoptAddresses bounds the addresses adopted in one request.
+const maxAdoptAddresses = 100
+
+var errNoGateways = errors.New("no gateways configured for network")
+
+// discover lists the applications delegated to each of the network's
+// gateways and compares them with its configured applications. Tracked apps
+// missing from the listings are queried to show where they are delegated
+// instead, or why they can't be found.
+func (s *Server) discover(ctx context.Context, network string, networkConfig config.NetworkConfig) (models.DiscoveryReport, error) {
+	if len(networkConfig.Gateways) == 0 {
+		return models.DiscoveryReport{}, errNoGateways
+	}
+
+	delegated := make(map[string]*models.Application)
+	for _, gateway := range networkConfig.Gateways {
+		apps, err := s.Client.ListApplications(ctx, networkConfig.APIEndpoint, network, gateway)
+		if err != nil {
+			return models.DiscoveryReport{}, fmt.Errorf("failed to list applications of gateway %s: %w", gateway, err)
+		}
+		for _, app := range apps {
+			delegated[app.Address] = app
+		}
+	}
+
+	report := models.DiscoveryReport{
+		Network:      network,
+		Gateways:     networkConfig.Gateways,
+		Untracked:    []models.DiscoveredApp{},
+		NotDelegated: []models.DiscoveredApp{},
+		CheckedAt:    time.Now(),
+	}
+
+	var missing []string
+	for _, address := range networkConfig.Applications {
+		if _, ok := delegated[address]; !ok {
+			missing = append(missing, address)
+		}
+	}
+	for address, app := range delegated {
+		if !slices.Contains(networkConfig.Applications, address) {
+			report.Untracked = append(report.Untracked, discoveredApp(app))
+		}
+	}
+	sort.Slice(report.Untracked, func(i, j int) bool {
+		return report.Untracked[i].Address < report.Untracked[j].Address
+	})
+
+	notDelegated := make([]models.DiscoveredApp, len(missing))
+	pocket.ForEach(ctx, pocket.LimitsFor(networkConfig).Concurrency, missing, func(ctx context.Context, i int, address string) {
+		app, err := s.Client.QueryApplication(ctx, address, networkConfig.APIEndpoint, network, networkConfig.BaseDenom())
+		if err != nil {
+			notDelegated[i] = models.DiscoveredApp{Address: address, Error: err.Error()}
+			return
+		}
+		notDelegated[i] = discoveredApp(app)
+	})
+	for i, address := range missing {
+		if notDelegated[i].Address == "" {
+			notDelegated[i] = models.DiscoveredApp{Address: address, Error: fmt.Sprintf("not queried: %v", ctx.Err())}
+		}
+	}
+	report.NotDelegated = append(report.NotDelegated, notDelegated...)
+
+	return report, nil
+}
+
+func discoveredApp(app *models.Application) models.DiscoveredApp {
+	return models.DiscoveredApp{
+		Address:   app.Address,
+		ServiceID: app.ServiceID,
+		Stake:     app.Stake,
+		Gateway:   app.Gateway,
+	}
+}
+
+// refreshDiscovery runs discovery for a network and stores the report in
+// Discovery. Concurrent calls for the same network share one run.
+func (s *Server) refreshDiscovery(network string, networkConfig config.NetworkConfig) (models.DiscoveryReport, error) {
+	report, err, _ := s.discoveryFlight.Do(network, func() (models.DiscoveryReport, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), refreshTimeout)
+		defer cancel()
+
+		report, err := s.discover(ctx, network, networkConfig)
+		if err != nil {
+			return models.DiscoveryReport{}, err
+		}
+		if len(report.Untracked) > 0 || len(report.NotDelegated) > 0 {
+			s.Logger.Warn("discovery found applications out of sync with config",
+				"network", network, "untracked", len(report.Untracked), "not_delegated", len(report.NotDelegated))
+		}
+		s.Discovery.Set(network, report)
+		return report, nil
+	})
+	return report, err
+}
+
+// DiscoverAll runs discovery for every network with gateways.
+func (s *Server) DiscoverAll() {
+	for network, networkConfig := range s.Config.Config.Networks {
+		if len(networkConfig.Gateways) == 0 {
+			continue
+		}
+		if _, err := s.refreshDiscovery(network, networkConfig); err != nil {
+			s.Logger.Warn("application discovery failed", "network", network, "error", err)
+		}
+	}
+}
+
+// RunDiscovery runs discovery right away and then every interval until ctx
+// is cancelled.
+func (s *Server) RunDiscovery(ctx context.Context, interval time.Duration) {
+	s.Logger.Info("application discovery started", "interval", interval)
+	s.DiscoverAll()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.Logger.Info("application discovery stopped")
+			return
+		case <-ticker.C:
+			s.DiscoverAll()
+		}
+	}
+}
+
+func (s *Server) handleGetDiscovery(w http.ResponseWriter, r *http.Request) {
+	network := r.URL.Query().Get("network")
+	if network == "" {
+		network = "pocket"
+	}
+
+	networkConfig, ok := s.Config.Config.Networks[network]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, "invalid network")
+		return
+	}
+	if len(networkConfig.Gateways) == 0 {
+		respondWithError(w, http.StatusBadRequest, errNoGateways.Error())
+		return
+	}
+
+	if r.URL.Query().Get("refresh") != "true" {
+		if report, ok := s.Discovery.Get(network); ok {
+			respondWithJSON(w, http.StatusOK, report)
+			return
+		}
+	}
+
+	report, err := s.refreshDiscovery(network, networkConfig)
+	if err != nil {
+		s.Logger.Error("application discovery failed", "network", network, "error", err)
+		respondWithError(w, http.StatusInternalServerError, "failed to discover applications")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, report)
+}
+
+// handleAdoptDiscovered adds untracked applications from the latest
+// discovery report to config. Only apps delegated to one of the network's
+// gateways can be adopted.
+func (s *Server) handleAdoptDiscovered(w http.ResponseWriter, r *http.Request) {
+	network := r.URL.Query().Get("network")
+	if network == "" {
+		network = "pocket"
+	}
+
+	networkConfig, ok := s.Config.Config.Networks[network]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, "invalid network")
+		return
+	}
+	if len(networkConfig.Gateways) == 0 {
+		respondWithError(w, http.StatusBadRequest, errNoGateways.Error())
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, 16*1024)
+	var req models.AdoptRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if len(req.Addresses) == 0 {
+		respondWithError(w, http.StatusBadRequest, "no addresses to adopt")
+		return
+	}
+	if len(req.Addresses) > maxAdoptAddresses {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("at most %d addresses can be adopted at once", maxAdoptAddresses))
+		return
+	}
+
+	report, ok := s.Discovery.Get(network)
+	if !ok {
+		var err error
+		if report, err = s.refreshDiscovery(network, networkConfig); err != nil {
+			s.Logger.Error("application discovery failed", "network", network, "error", err)
+			respondWithError(w, http.StatusInternalServerError, "failed to discover applications")
+			return
+		}
+	}
+
+	resp := models.AdoptResponse{Network: network, Adopted: []string{}, Skipped: []models.AdoptSkipped{}}
+	for _, address := range req.Addresses {
+		skip := func(reason string) {
+			resp.Skipped = append(resp.Skipped, models.AdoptSkipped{Address: address, Reason: reason})
+		}
+		if err := validate.Address(address); err != nil {
+			skip("invalid address format")
+			continue
+		}
+		if slices.Contains(networkConfig.Applications, address) || slices.Contains(resp.Adopted, address) {
+			skip("already tracked")
+			continue
+		}
+		if !slices.ContainsFunc(report.Untracked, func(app models.DiscoveredApp) bool { return app.Address == address }) {
+			skip("not delegated to a configured gateway")
+			continue
+		}
+		if err := s.trackApplication(network, address); err != nil {
+			s.Logger.Error("failed to adopt application", "network", network, "address", address, "error", err)
+			skip(err.Error())
+			continue
+		}
+		s.Logger.Info("adopted discovered application", "network", network, "address", address)
+		resp.Adopted = append(resp.Adopted, address)
+	}
+
+	if len(resp.Adopted) > 0 {
+		s.AppCache.Delete(network)
+		s.Discovery.Delete(network)
+	}
+
+	respondWithJSON(w, http.StatusOK, resp)
+}
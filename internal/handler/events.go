@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"github.com/pokt-network/sam/internal/pocket"
+)
+
+// OnChainEvent is the pocket.Subscriber callback. It drops the cached
+// applications or bank account an event made out of date, and wakes the
+// auto top-up worker for each app it touched.
+func (s *Server) OnChainEvent(event pocket.ChainEvent) {
+	networkConfig, ok := s.Config.Config.Networks[event.Network]
+	if !ok {
+		return
+	}
+
+	for _, address := range event.Addresses {
+		if address == networkConfig.Bank {
+			s.BankCache.Delete(event.Network)
+			continue
+		}
+		s.AppCache.Delete(event.Network)
+		if s.Worker != nil {
+			s.Worker.Wake(event.Network, address)
+		}
+	}
+}
@@ -1,33 +1,59 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"os/exec"
+	"slices"
+	"sort"
+	"time"
 
 	"github.com/gorilla/mux"
 
 	"github.com/pokt-network/sam/internal/autotopup"
 	"github.com/pokt-network/sam/internal/cache"
 	"github.com/pokt-network/sam/internal/config"
+	"github.com/pokt-network/sam/internal/history"
 	"github.com/pokt-network/sam/internal/models"
+	"github.com/pokt-network/sam/internal/pendingtx"
 	"github.com/pokt-network/sam/internal/pocket"
 	"github.com/pokt-network/sam/internal/validate"
 )
 
 // Server holds all dependencies for HTTP handlers.
 type Server struct {
-	Config     *config.Config
-	ConfigPath string
-	Client     *pocket.Client
-	Executor   *pocket.Executor
-	AppCache   *cache.Cache[[]models.Application]
-	BankCache  *cache.Cache[models.BankAccount]
-	AutoTopUp  *autotopup.Store
-	Worker     *autotopup.Worker
-	Logger     *slog.Logger
+	Config       *config.Config
+	ConfigPath   string
+	Client       pocket.ChainReader
+	Executor     pocket.TxSubmitter
+	AppCache     *cache.Cache[models.ApplicationsResponse]
+	LastGood     *cache.Cache[models.Application] // last successful query per network/address
+	BankCache    *cache.Cache[models.BankAccount]
+	Discovery    *cache.Cache[models.DiscoveryReport]
+	ServiceCache *cache.Cache[[]models.ServiceInfo] // full service catalog per network
+	ParamsCache  *cache.Cache[models.ChainParams]
+	AutoTopUp    *autotopup.Store
+	Sweeps       *autotopup.SweepStore
+	Pending      *pendingtx.Store
+	History      *history.Store // stake and balance snapshots, recorded on refresh; nil serves empty series
+	Worker       *autotopup.Worker
+	Endpoints    *pocket.Endpoints
+	Logger       *slog.Logger
+
+	// Simulated is set when the chain is the built-in simulation, which
+	// needs neither pocketd nor a keyring.
+	Simulated bool
+
+	// Deduplicate concurrent fetches of the same network.
+	appFlight       cache.Flight[models.ApplicationsResponse]
+	bankFlight      cache.Flight[models.BankAccount]
+	discoveryFlight cache.Flight[models.DiscoveryReport]
+	serviceFlight   cache.Flight[[]models.ServiceInfo]
+	paramsFlight    cache.Flight[models.ChainParams]
 }
 
 func (s *Server) handleGetApplications(w http.ResponseWriter, r *http.Request) {
@@ -38,6 +64,20 @@ func (s *Server) handleGetApplications(w http.ResponseWriter, r *http.Request) {
 
 	forceRefresh := r.URL.Query().Get("refresh") == "true"
 
+	// Version 1 (the default) is a bare array of applications; version 2
+	// wraps it with per-app errors; version 3 also writes amounts as strings.
+	version := 1
+	switch r.URL.Query().Get("version") {
+	case "", "1":
+	case "2":
+		version = models.ApplicationsResponseVersion
+	case "3":
+		version = models.AmountsVersion
+	default:
+		respondWithError(w, http.StatusBadRequest, "unsupported version")
+		return
+	}
+
 	s.Logger.Info("fetching applications", "network", network, "force_refresh", forceRefresh)
 
 	networkConfig, ok := s.Config.Config.Networks[network]
@@ -47,49 +87,88 @@ func (s *Server) handleGetApplications(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	respond := func(resp models.ApplicationsResponse) {
+		switch version {
+		case 1:
+			respondWithJSON(w, http.StatusOK, resp.Applications)
+		case models.AmountsVersion:
+			respondWithJSON(w, http.StatusOK, resp.V3(networkConfig.BaseDenom()))
+		default:
+			respondWithJSON(w, http.StatusOK, resp)
+		}
+	}
+
+	// Serve cached data at once, refreshing it in the background if it has
+	// expired. ?refresh=true waits for a fetch.
 	if !forceRefresh {
-		if apps, ok := s.AppCache.Get(network); ok {
-			s.Logger.Info("returning cached applications", "count", len(apps))
-			respondWithJSON(w, http.StatusOK, apps)
+		if resp, expired, ok := s.AppCache.GetStale(network); ok {
+			if expired {
+				go s.refreshApplications(network, networkConfig)
+			}
+			s.Logger.Info("returning cached applications", "count", len(resp.Applications), "expired", expired)
+			respond(resp)
 			return
 		}
 	}
 
-	s.Logger.Info("querying applications in parallel via API", "count", len(networkConfig.Applications))
+	resp := s.refreshApplications(network, networkConfig)
 
-	type result struct {
-		app *models.Application
-		err error
-	}
-
-	results := make(chan result, len(networkConfig.Applications))
+	s.Logger.Info("fetched applications", "success", len(resp.Applications)-countStale(resp.Errors), "failed", len(resp.Errors), "total", len(networkConfig.Applications))
+	respond(resp)
+}
 
-	for _, appAddress := range networkConfig.Applications {
-		go func(addr string) {
-			defer func() {
-				if r := recover(); r != nil {
-					results <- result{err: fmt.Errorf("panic querying application %s: %v", addr, r)}
-				}
-			}()
-			app, err := s.Client.QueryApplication(addr, networkConfig.APIEndpoint, network)
-			results <- result{app: app, err: err}
-		}(appAddress)
+// fetchApplications loads every configured application, and the bank's
+// grants to all of them, with list queries. An app whose query fails is
+// reported in Errors and, if it was fetched successfully before, included
+// with its last known value marked stale.
+func (s *Server) fetchApplications(ctx context.Context, network string, networkConfig config.NetworkConfig) models.ApplicationsResponse {
+	s.Logger.Info("querying applications via API", "count", len(networkConfig.Applications))
+
+	addresses := networkConfig.Applications
+	apps, errs := pocket.LoadApplications(ctx, s.Client, s.Logger, network, networkConfig, addresses)
+	s.attachAllGrants(ctx, apps, networkConfig)
+
+	now := time.Now()
+	resp := models.ApplicationsResponse{
+		Version:      models.ApplicationsResponseVersion,
+		Network:      network,
+		Applications: make([]models.Application, 0, len(addresses)),
+		Errors:       []models.ApplicationError{},
+		FetchedAt:    now,
 	}
 
-	var applications []models.Application
-	for i := 0; i < len(networkConfig.Applications); i++ {
-		res := <-results
-		if res.err != nil {
-			s.Logger.Error("failed to query application", "error", res.err)
+	for i, err := range errs {
+		key := network + "/" + addresses[i]
+		if err == nil {
+			app := *apps[i]
+			app.FetchedAt = &now
+			s.LastGood.Set(key, app)
+			resp.Applications = append(resp.Applications, app)
+			resp.Height = max(resp.Height, app.Height)
 			continue
 		}
-		applications = append(applications, *res.app)
+
+		s.Logger.Error("failed to query application", "address", addresses[i], "error", err)
+		appErr := models.ApplicationError{Address: addresses[i], Error: err.Error()}
+		if app, ok := s.LastGood.Get(key); ok {
+			app.Stale = true
+			resp.Applications = append(resp.Applications, app)
+			appErr.Stale = true
+		}
+		resp.Errors = append(resp.Errors, appErr)
 	}
 
-	s.AppCache.Set(network, applications)
+	return resp
+}
 
-	s.Logger.Info("fetched applications", "success", len(applications), "total", len(networkConfig.Applications))
-	respondWithJSON(w, http.StatusOK, applications)
+func countStale(errs []models.ApplicationError) int {
+	n := 0
+	for _, e := range errs {
+		if e.Stale {
+			n++
+		}
+	}
+	return n
 }
 
 func (s *Server) handleGetApplication(w http.ResponseWriter, r *http.Request) {
@@ -106,6 +185,12 @@ func (s *Server) handleGetApplication(w http.ResponseWriter, r *http.Request) {
 		network = "pocket"
 	}
 
+	stringAmounts, ok := amountsVersion(r)
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, "unsupported version")
+		return
+	}
+
 	s.Logger.Info("fetching application", "address", address, "network", network)
 
 	networkConfig, ok := s.Config.Config.Networks[network]
@@ -114,29 +199,506 @@ func (s *Server) handleGetApplication(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	app, err := s.Client.QueryApplication(address, networkConfig.APIEndpoint, network)
-	if err != nil {
-		s.Logger.Error("error querying application", "error", err)
-		respondWithError(w, http.StatusInternalServerError, "failed to query application")
+	// The height is read first: the app's data is at least that recent.
+	block, err := s.Client.QueryLatestBlock(r.Context(), networkConfig.APIEndpoint)
+	if err != nil {
+		s.Logger.Warn("failed to query latest block", "network", network, "error", err)
+	}
+
+	app, err := s.Client.QueryApplication(r.Context(), address, networkConfig.APIEndpoint, network, networkConfig.BaseDenom())
+	if err != nil {
+		s.Logger.Error("error querying application", "error", err)
+		respondWithError(w, http.StatusInternalServerError, "failed to query application")
+		return
+	}
+	if block != nil {
+		app.Height = block.Height
+	}
+	s.attachGrants(r.Context(), app, networkConfig)
+
+	if stringAmounts {
+		respondWithJSON(w, http.StatusOK, app.V3(networkConfig.BaseDenom()))
+		return
+	}
+	respondWithJSON(w, http.StatusOK, app)
+}
+
+// amountsVersion reads ?version= on endpoints that are otherwise
+// unversioned. It reports whether version 3 (amounts as base-denom strings) was
+// requested, and false for ok if the version is unsupported.
+func amountsVersion(r *http.Request) (stringAmounts, ok bool) {
+	switch r.URL.Query().Get("version") {
+	case "", "1", "2":
+		return false, true
+	case "3":
+		return true, true
+	default:
+		return false, false
+	}
+}
+
+// baseDenom returns network's base denom, upokt for an unknown network.
+func (s *Server) baseDenom(network string) string {
+	return s.Config.Config.Networks[network].BaseDenom()
+}
+
+// attachAllGrants sets the bank's authz stake grants and fee allowances on
+// apps from one listing of each for the whole network. Nil apps are
+// skipped.
+func (s *Server) attachAllGrants(ctx context.Context, apps []*models.Application, networkConfig config.NetworkConfig) {
+	if networkConfig.Bank == "" {
+		return
+	}
+
+	grants, err := s.Client.ListStakeGrants(ctx, networkConfig.Bank, networkConfig.APIEndpoint)
+	if err != nil {
+		s.Logger.Warn("failed to list stake grants", "bank", networkConfig.Bank, "error", err)
+	}
+	allowances, err := s.Client.ListFeeAllowances(ctx, networkConfig.Bank, networkConfig.APIEndpoint, networkConfig.BaseDenom())
+	if err != nil {
+		s.Logger.Warn("failed to list fee allowances", "bank", networkConfig.Bank, "error", err)
+	}
+
+	for _, app := range apps {
+		if app == nil {
+			continue
+		}
+		app.StakeGrant = grants[app.Address]
+		app.FeeAllowance = allowances[app.Address]
+	}
+}
+
+// attachGrants sets the bank's authz stake grant and fee allowance for the
+// app, if any.
+func (s *Server) attachGrants(ctx context.Context, app *models.Application, networkConfig config.NetworkConfig) {
+	if networkConfig.Bank == "" {
+		return
+	}
+
+	grant, err := s.Client.QueryStakeGrant(ctx, app.Address, networkConfig.Bank, networkConfig.APIEndpoint)
+	if err != nil {
+		s.Logger.Warn("failed to query stake grant", "address", app.Address, "error", err)
+	} else {
+		app.StakeGrant = grant
+	}
+
+	allowance, err := s.Client.QueryFeeAllowance(ctx, networkConfig.Bank, app.Address, networkConfig.APIEndpoint, networkConfig.BaseDenom())
+	if err != nil {
+		s.Logger.Warn("failed to query fee allowance", "address", app.Address, "error", err)
+	} else {
+		app.FeeAllowance = allowance
+	}
+}
+
+func (s *Server) handleGetStakeGrant(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	address := vars["address"]
+
+	if err := validate.Address(address); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid address format")
+		return
+	}
+
+	network := r.URL.Query().Get("network")
+	if network == "" {
+		network = "pocket"
+	}
+
+	networkConfig, ok := s.Config.Config.Networks[network]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, "invalid network")
+		return
+	}
+
+	if networkConfig.Bank == "" {
+		respondWithError(w, http.StatusBadRequest, "no bank account configured for network")
+		return
+	}
+
+	grant, err := s.Client.QueryStakeGrant(r.Context(), address, networkConfig.Bank, networkConfig.APIEndpoint)
+	if err != nil {
+		s.Logger.Error("error querying stake grant", "error", err)
+		respondWithError(w, http.StatusInternalServerError, "failed to query stake grant")
+		return
+	}
+	if grant == nil {
+		respondWithError(w, http.StatusNotFound, "no stake grant for application")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, grant)
+}
+
+func (s *Server) handleGrantStake(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	address := vars["address"]
+
+	if err := validate.Address(address); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid address format")
+		return
+	}
+
+	network := r.URL.Query().Get("network")
+	if network == "" {
+		network = "pocket"
+	}
+
+	networkConfig, ok := s.Config.Config.Networks[network]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, "invalid network")
+		return
+	}
+
+	if networkConfig.Bank == "" {
+		respondWithError(w, http.StatusBadRequest, "no bank account configured for network")
+		return
+	}
+
+	// The body is optional; without it the grant never expires.
+	r.Body = http.MaxBytesReader(w, r.Body, 1024)
+	var req models.AuthzGrantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		respondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.ExpirationDays < 0 {
+		respondWithError(w, http.StatusBadRequest, "expiration_days must not be negative")
+		return
+	}
+
+	s.Logger.Info("granting stake authz", "address", address, "network", network, "expiration_days", req.ExpirationDays)
+
+	result, err := s.Executor.GrantStakeAuthz(r.Context(), address, networkConfig.Bank, network, req.ExpirationDays, networkConfig.RPCEndpoint)
+	if err != nil {
+		s.Logger.Error("authz grant error", "error", err)
+		respondWithError(w, http.StatusInternalServerError, "authz grant failed")
+		return
+	}
+
+	s.AppCache.Delete(network)
+
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleRevokeStake(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	address := vars["address"]
+
+	if err := validate.Address(address); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid address format")
+		return
+	}
+
+	network := r.URL.Query().Get("network")
+	if network == "" {
+		network = "pocket"
+	}
+
+	networkConfig, ok := s.Config.Config.Networks[network]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, "invalid network")
+		return
+	}
+
+	if networkConfig.Bank == "" {
+		respondWithError(w, http.StatusBadRequest, "no bank account configured for network")
+		return
+	}
+
+	s.Logger.Info("revoking stake authz", "address", address, "network", network)
+
+	result, err := s.Executor.RevokeStakeAuthz(r.Context(), address, networkConfig.Bank, network, networkConfig.RPCEndpoint)
+	if err != nil {
+		s.Logger.Error("authz revoke error", "error", err)
+		respondWithError(w, http.StatusInternalServerError, "authz revoke failed")
+		return
+	}
+
+	s.AppCache.Delete(network)
+
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleGetBank(w http.ResponseWriter, r *http.Request) {
+	network := r.URL.Query().Get("network")
+	if network == "" {
+		network = "pocket"
+	}
+
+	forceRefresh := r.URL.Query().Get("refresh") == "true"
+
+	stringAmounts, ok := amountsVersion(r)
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, "unsupported version")
+		return
+	}
+	respond := func(bank models.BankAccount) {
+		if stringAmounts {
+			respondWithJSON(w, http.StatusOK, bank.V3())
+			return
+		}
+		respondWithJSON(w, http.StatusOK, bank)
+	}
+
+	s.Logger.Info("fetching bank account", "network", network, "force_refresh", forceRefresh)
+
+	networkConfig, ok := s.Config.Config.Networks[network]
+	if !ok {
+		s.Logger.Warn("invalid network requested", "network", network)
+		respondWithError(w, http.StatusBadRequest, "invalid network")
+		return
+	}
+
+	if networkConfig.Bank == "" {
+		respondWithError(w, http.StatusBadRequest, "no bank account configured for network")
+		return
+	}
+
+	if !forceRefresh {
+		if bank, expired, ok := s.BankCache.GetStale(network); ok {
+			if expired {
+				go func() {
+					if _, err := s.refreshBank(network, networkConfig); err != nil {
+						s.Logger.Warn("background bank refresh failed", "network", network, "error", err)
+					}
+				}()
+			}
+			s.Logger.Info("returning cached bank account", "expired", expired)
+			respond(bank)
+			return
+		}
+	}
+
+	bank, err := s.refreshBank(network, networkConfig)
+	if err != nil {
+		s.Logger.Error("error querying bank account", "error", err)
+		respondWithError(w, http.StatusInternalServerError, "failed to query bank account")
+		return
+	}
+
+	respond(bank)
+}
+
+func (s *Server) handleUpstake(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	address := vars["address"]
+
+	if err := validate.Address(address); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid address format")
+		return
+	}
+
+	network := r.URL.Query().Get("network")
+	if network == "" {
+		network = "pocket"
+	}
+
+	networkConfig, ok := s.Config.Config.Networks[network]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, "invalid network")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, 1024)
+	var req models.StakeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	amountUpokt, err := validate.POKTAmount(req.Amount, networkConfig.BaseDenom())
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Only an upstake smaller than the minimum itself can leave the stake
+	// below it; look up the current stake just for those.
+	if minStake := s.minStake(network, networkConfig); amountUpokt < minStake {
+		current, err := s.currentStake(r.Context(), network, networkConfig, address)
+		if err != nil {
+			s.Logger.Warn("skipping min stake check", "address", address, "error", err)
+		} else if newStake, err := validate.StakeAddition(current, amountUpokt); err == nil && newStake < minStake {
+			respondWithError(w, http.StatusBadRequest, belowMinStake("resulting stake", minStake))
+			return
+		}
+	}
+
+	s.Logger.Info("upstaking", "address", address, "pokt", req.Amount, "upokt", amountUpokt)
+
+	result, err := s.Executor.UpstakeApplication(r.Context(), address, networkConfig.Bank, network, amountUpokt, networkConfig.RPCEndpoint, networkConfig.APIEndpoint)
+	if err != nil {
+		s.Logger.Error("upstake error", "error", err)
+		respondWithError(w, http.StatusInternalServerError, "upstake operation failed")
+		return
+	}
+
+	s.AppCache.Delete(network)
+	s.BankCache.Delete(network)
+
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleFund(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	address := vars["address"]
+
+	if err := validate.Address(address); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid address format")
+		return
+	}
+
+	network := r.URL.Query().Get("network")
+	if network == "" {
+		network = "pocket"
+	}
+
+	networkConfig, ok := s.Config.Config.Networks[network]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, "invalid network")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, 1024)
+	var req models.StakeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	amountUpokt, err := validate.POKTAmount(req.Amount, networkConfig.BaseDenom())
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.Logger.Info("funding", "address", address, "pokt", req.Amount, "upokt", amountUpokt)
+
+	result, err := s.Executor.FundApplication(r.Context(), address, networkConfig.Bank, network, amountUpokt, networkConfig.RPCEndpoint)
+	if err != nil {
+		s.Logger.Error("fund error", "error", err)
+		respondWithError(w, http.StatusInternalServerError, "fund operation failed")
+		return
+	}
+
+	s.AppCache.Delete(network)
+	s.BankCache.Delete(network)
+
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleGetFeeGrant(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	address := vars["address"]
+
+	if err := validate.Address(address); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid address format")
+		return
+	}
+
+	network := r.URL.Query().Get("network")
+	if network == "" {
+		network = "pocket"
+	}
+
+	networkConfig, ok := s.Config.Config.Networks[network]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, "invalid network")
+		return
+	}
+
+	if networkConfig.Bank == "" {
+		respondWithError(w, http.StatusBadRequest, "no bank account configured for network")
+		return
+	}
+
+	allowance, err := s.Client.QueryFeeAllowance(r.Context(), networkConfig.Bank, address, networkConfig.APIEndpoint, networkConfig.BaseDenom())
+	if err != nil {
+		s.Logger.Error("error querying fee allowance", "error", err)
+		respondWithError(w, http.StatusInternalServerError, "failed to query fee allowance")
+		return
+	}
+	if allowance == nil {
+		respondWithError(w, http.StatusNotFound, "no fee allowance for application")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, allowance)
+}
+
+func (s *Server) handleSetFeeGrant(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	address := vars["address"]
+
+	if err := validate.Address(address); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid address format")
+		return
+	}
+
+	network := r.URL.Query().Get("network")
+	if network == "" {
+		network = "pocket"
+	}
+
+	networkConfig, ok := s.Config.Config.Networks[network]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, "invalid network")
+		return
+	}
+
+	if networkConfig.Bank == "" {
+		respondWithError(w, http.StatusBadRequest, "no bank account configured for network")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, 1024)
+	var req models.FeeGrantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	spendLimit, err := validate.POKTAmount(req.SpendLimit, networkConfig.BaseDenom())
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("invalid spend limit: %s", err.Error()))
+		return
+	}
+	if req.ExpirationDays < 0 {
+		respondWithError(w, http.StatusBadRequest, "expiration_days must not be negative")
+		return
+	}
+
+	s.Logger.Info("granting fee allowance", "address", address, "network", network, "upokt", spendLimit)
+
+	result, err := s.Executor.GrantFeeAllowance(r.Context(), address, networkConfig.Bank, network, spendLimit, req.ExpirationDays, networkConfig.RPCEndpoint)
+	if err != nil {
+		s.Logger.Error("feegrant error", "error", err)
+		respondWithError(w, http.StatusInternalServerError, "fee grant failed")
+		return
+	}
+
+	s.AppCache.Delete(network)
+	s.BankCache.Delete(network)
+
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleRevokeFeeGrant(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	address := vars["address"]
+
+	if err := validate.Address(address); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid address format")
 		return
 	}
 
-	respondWithJSON(w, http.StatusOK, app)
-}
-
-func (s *Server) handleGetBank(w http.ResponseWriter, r *http.Request) {
 	network := r.URL.Query().Get("network")
 	if network == "" {
 		network = "pocket"
 	}
 
-	forceRefresh := r.URL.Query().Get("refresh") == "true"
-
-	s.Logger.Info("fetching bank account", "network", network, "force_refresh", forceRefresh)
-
 	networkConfig, ok := s.Config.Config.Networks[network]
 	if !ok {
-		s.Logger.Warn("invalid network requested", "network", network)
 		respondWithError(w, http.StatusBadRequest, "invalid network")
 		return
 	}
@@ -146,27 +708,21 @@ func (s *Server) handleGetBank(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !forceRefresh {
-		if bank, ok := s.BankCache.Get(network); ok {
-			s.Logger.Info("returning cached bank account")
-			respondWithJSON(w, http.StatusOK, bank)
-			return
-		}
-	}
+	s.Logger.Info("revoking fee allowance", "address", address, "network", network)
 
-	bank, err := s.Client.QueryBankAccount(networkConfig.Bank, networkConfig.APIEndpoint, network)
+	result, err := s.Executor.RevokeFeeAllowance(r.Context(), address, networkConfig.Bank, network, networkConfig.RPCEndpoint)
 	if err != nil {
-		s.Logger.Error("error querying bank account", "error", err)
-		respondWithError(w, http.StatusInternalServerError, "failed to query bank account")
+		s.Logger.Error("feegrant revoke error", "error", err)
+		respondWithError(w, http.StatusInternalServerError, "fee grant revoke failed")
 		return
 	}
 
-	s.BankCache.Set(network, *bank)
+	s.AppCache.Delete(network)
 
-	respondWithJSON(w, http.StatusOK, bank)
+	respondWithJSON(w, http.StatusOK, result)
 }
 
-func (s *Server) handleUpstake(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleSweep(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	address := vars["address"]
 
@@ -186,25 +742,58 @@ func (s *Server) handleUpstake(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if networkConfig.Bank == "" {
+		respondWithError(w, http.StatusBadRequest, "no bank account configured for network")
+		return
+	}
+
+	// Only configured apps are swept, so a request can't drain any other
+	// account the keyring happens to hold.
+	if !slices.Contains(networkConfig.Applications, address) {
+		respondWithError(w, http.StatusBadRequest, "application is not configured for network")
+		return
+	}
+
+	// The body is optional; an empty body sweeps down to the stored policy floor.
 	r.Body = http.MaxBytesReader(w, r.Body, 1024)
-	var req models.StakeRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	var req models.SweepRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
 		respondWithError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
-	amountUpokt, err := validate.POKTAmount(req.Amount)
+	var floor int64
+	if req.Floor != nil {
+		f, err := validate.POKTFloor(*req.Floor, networkConfig.BaseDenom())
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, fmt.Sprintf("invalid floor: %s", err.Error()))
+			return
+		}
+		floor = f
+	} else if policy, ok := s.Sweeps.Get(network, address); ok {
+		floor = policy.Floor
+	}
+
+	balance, err := s.Client.QueryBalance(r.Context(), address, networkConfig.APIEndpoint, networkConfig.BaseDenom())
 	if err != nil {
-		respondWithError(w, http.StatusBadRequest, err.Error())
+		s.Logger.Error("error querying balance for sweep", "error", err)
+		respondWithError(w, http.StatusInternalServerError, "failed to query application balance")
 		return
 	}
 
-	s.Logger.Info("upstaking", "address", address, "pokt", req.Amount, "upokt", amountUpokt)
+	amount := autotopup.SweepAmount(balance, floor)
+	if amount <= 0 {
+		respondWithError(w, http.StatusBadRequest, "nothing to sweep: liquid balance is at or below the floor")
+		return
+	}
+
+	s.Logger.Info("sweeping", "address", address, "balance", balance, "floor", floor, "upokt", amount)
 
-	result, err := s.Executor.UpstakeApplication(address, networkConfig.Bank, network, amountUpokt, networkConfig.RPCEndpoint, networkConfig.APIEndpoint)
+	result, err := s.Executor.SweepApplication(r.Context(), address, networkConfig.Bank, network, amount, networkConfig.RPCEndpoint)
+	s.Worker.RecordSweep(network, address, amount, result, err)
 	if err != nil {
-		s.Logger.Error("upstake error", "error", err)
-		respondWithError(w, http.StatusInternalServerError, "upstake operation failed")
+		s.Logger.Error("sweep error", "error", err)
+		respondWithError(w, http.StatusInternalServerError, "sweep operation failed")
 		return
 	}
 
@@ -214,7 +803,17 @@ func (s *Server) handleUpstake(w http.ResponseWriter, r *http.Request) {
 	respondWithJSON(w, http.StatusOK, result)
 }
 
-func (s *Server) handleFund(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleGetSweep(w http.ResponseWriter, r *http.Request) {
+	network := r.URL.Query().Get("network")
+	if network == "" {
+		network = "pocket"
+	}
+
+	configs := s.Sweeps.GetAll(network)
+	respondWithJSON(w, http.StatusOK, configs)
+}
+
+func (s *Server) handleSetSweepPolicy(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	address := vars["address"]
 
@@ -234,32 +833,66 @@ func (s *Server) handleFund(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The worker sweeps every enabled policy, so policies are limited to
+	// configured apps like on-demand sweeps.
+	if !slices.Contains(networkConfig.Applications, address) {
+		respondWithError(w, http.StatusBadRequest, "application is not configured for network")
+		return
+	}
+
 	r.Body = http.MaxBytesReader(w, r.Body, 1024)
-	var req models.StakeRequest
+	var req models.SweepPolicyRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		respondWithError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
-	amountUpokt, err := validate.POKTAmount(req.Amount)
+	floorUpokt, err := validate.POKTFloor(req.Floor, networkConfig.BaseDenom())
 	if err != nil {
-		respondWithError(w, http.StatusBadRequest, err.Error())
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("invalid floor: %s", err.Error()))
 		return
 	}
 
-	s.Logger.Info("funding", "address", address, "pokt", req.Amount, "upokt", amountUpokt)
+	cfg := models.SweepConfig{
+		Enabled: req.Enabled,
+		Floor:   floorUpokt,
+	}
 
-	result, err := s.Executor.FundApplication(address, networkConfig.Bank, network, amountUpokt, networkConfig.RPCEndpoint)
-	if err != nil {
-		s.Logger.Error("fund error", "error", err)
-		respondWithError(w, http.StatusInternalServerError, "fund operation failed")
+	if err := s.Sweeps.Set(network, address, cfg); err != nil {
+		s.Logger.Error("failed to save sweep policy", "error", err)
+		respondWithError(w, http.StatusInternalServerError, "failed to save config")
 		return
 	}
 
-	s.AppCache.Delete(network)
-	s.BankCache.Delete(network)
+	s.Logger.Info("sweep policy updated",
+		"address", address, "network", network, "enabled", req.Enabled)
 
-	respondWithJSON(w, http.StatusOK, result)
+	respondWithJSON(w, http.StatusOK, cfg)
+}
+
+func (s *Server) handleDeleteSweepPolicy(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	address := vars["address"]
+
+	if err := validate.Address(address); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid address format")
+		return
+	}
+
+	network := r.URL.Query().Get("network")
+	if network == "" {
+		network = "pocket"
+	}
+
+	if err := s.Sweeps.Delete(network, address); err != nil {
+		s.Logger.Error("failed to delete sweep policy", "error", err)
+		respondWithError(w, http.StatusInternalServerError, "failed to delete config")
+		return
+	}
+
+	s.Logger.Info("sweep policy deleted", "address", address, "network", network)
+
+	respondWithJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
 }
 
 func (s *Server) handleGetServices(w http.ResponseWriter, r *http.Request) {
@@ -274,7 +907,24 @@ func (s *Server) handleGetServices(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	services, err := s.Client.QueryServices(networkConfig.APIEndpoint)
+	if r.URL.Query().Get("refresh") != "true" {
+		if services, ok := s.ServiceCache.Get(network); ok {
+			respondWithJSON(w, http.StatusOK, services)
+			return
+		}
+	}
+
+	services, err, _ := s.serviceFlight.Do(network, func() ([]models.ServiceInfo, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), refreshTimeout)
+		defer cancel()
+
+		services, err := s.Client.QueryServices(ctx, networkConfig.APIEndpoint)
+		if err != nil {
+			return nil, err
+		}
+		s.ServiceCache.Set(network, services)
+		return services, nil
+	})
 	if err != nil {
 		s.Logger.Error("error querying services", "error", err)
 		respondWithError(w, http.StatusInternalServerError, "failed to query services")
@@ -313,12 +963,17 @@ func (s *Server) handleStakeNewApplication(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	amountUpokt, err := validate.POKTAmount(req.Amount)
+	amountUpokt, err := validate.POKTAmount(req.Amount, networkConfig.BaseDenom())
 	if err != nil {
 		respondWithError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
+	if minStake := s.minStake(network, networkConfig); amountUpokt < minStake {
+		respondWithError(w, http.StatusBadRequest, belowMinStake("stake amount", minStake))
+		return
+	}
+
 	s.Logger.Info("staking new application",
 		"address", req.Address,
 		"service_id", req.ServiceID,
@@ -326,7 +981,7 @@ func (s *Server) handleStakeNewApplication(w http.ResponseWriter, r *http.Reques
 		"upokt", amountUpokt,
 	)
 
-	result, err := s.Executor.StakeNewApplication(req.Address, req.ServiceID, network, amountUpokt, networkConfig.RPCEndpoint)
+	result, err := s.Executor.StakeNewApplication(r.Context(), req.Address, req.ServiceID, network, amountUpokt, networkConfig.RPCEndpoint)
 	if err != nil {
 		s.Logger.Error("stake new app error", "error", err)
 		respondWithError(w, http.StatusInternalServerError, "stake operation failed")
@@ -334,13 +989,8 @@ func (s *Server) handleStakeNewApplication(w http.ResponseWriter, r *http.Reques
 	}
 
 	if result.Success {
-		if err := s.Config.AddApplicationAddress(network, req.Address); err != nil {
-			s.Logger.Warn("failed to add address to in-memory config", "error", err)
-		} else if s.ConfigPath != "" {
-			if err := config.SaveApplicationAddress(s.ConfigPath, network, req.Address); err != nil {
-				s.Logger.Error("failed to persist address to config.yaml, rolling back in-memory change", "error", err)
-				s.Config.RemoveApplicationAddress(network, req.Address)
-			}
+		if err := s.trackApplication(network, req.Address); err != nil {
+			s.Logger.Warn("failed to add staked application to config", "error", err)
 		}
 	}
 
@@ -350,6 +1000,23 @@ func (s *Server) handleStakeNewApplication(w http.ResponseWriter, r *http.Reques
 	respondWithJSON(w, http.StatusOK, result)
 }
 
+// trackApplication adds address to the network's applications in memory
+// and in config.yaml. The in-memory change is rolled back if config.yaml
+// can't be written.
+func (s *Server) trackApplication(network, address string) error {
+	if err := s.Config.AddApplicationAddress(network, address); err != nil {
+		return err
+	}
+	if s.ConfigPath == "" {
+		return nil
+	}
+	if err := config.SaveApplicationAddress(s.ConfigPath, network, address); err != nil {
+		s.Config.RemoveApplicationAddress(network, address)
+		return fmt.Errorf("failed to persist address to config.yaml: %w", err)
+	}
+	return nil
+}
+
 func (s *Server) handleGetAutoTopUp(w http.ResponseWriter, r *http.Request) {
 	network := r.URL.Query().Get("network")
 	if network == "" {
@@ -381,13 +1048,13 @@ func (s *Server) handleSetAutoTopUp(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	triggerUpokt, err := validate.POKTAmount(req.TriggerThreshold)
+	triggerUpokt, err := validate.POKTAmount(req.TriggerThreshold, s.baseDenom(network))
 	if err != nil {
 		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("invalid trigger threshold: %s", err.Error()))
 		return
 	}
 
-	targetUpokt, err := validate.POKTAmount(req.TargetAmount)
+	targetUpokt, err := validate.POKTAmount(req.TargetAmount, s.baseDenom(network))
 	if err != nil {
 		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("invalid target amount: %s", err.Error()))
 		return
@@ -398,6 +1065,15 @@ func (s *Server) handleSetAutoTopUp(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The chain unstakes an app that drops below the minimum, so the
+	// trigger has to fire before that.
+	if networkConfig, ok := s.Config.Config.Networks[network]; ok {
+		if minStake := s.minStake(network, networkConfig); triggerUpokt < minStake {
+			respondWithError(w, http.StatusBadRequest, belowMinStake("trigger threshold", minStake))
+			return
+		}
+	}
+
 	cfg := models.AutoTopUpConfig{
 		Enabled:          req.Enabled,
 		TriggerThreshold: triggerUpokt,
@@ -446,12 +1122,259 @@ func (s *Server) handleGetAutoTopUpEvents(w http.ResponseWriter, _ *http.Request
 	respondWithJSON(w, http.StatusOK, events)
 }
 
-func (s *Server) handleGetNetworks(w http.ResponseWriter, _ *http.Request) {
+func (s *Server) handleGetPending(w http.ResponseWriter, r *http.Request) {
+	network := r.URL.Query().Get("network")
+	respondWithJSON(w, http.StatusOK, s.Pending.List(network))
+}
+
+func (s *Server) handleGetPendingTx(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if err := validate.PendingID(id); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tx, ok := s.Pending.Get(id)
+	if !ok {
+		respondWithError(w, http.StatusNotFound, "pending transaction not found")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, tx)
+}
+
+func (s *Server) handleDownloadUnsignedTx(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if err := validate.PendingID(id); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tx, ok := s.Pending.Get(id)
+	if !ok {
+		respondWithError(w, http.StatusNotFound, "pending transaction not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="unsigned-%s.json"`, tx.ID))
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(tx.UnsignedTx))
+}
+
+func (s *Server) handleSubmitSignedTx(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if err := validate.PendingID(id); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.expirePending()
+	tx, ok := s.Pending.Get(id)
+	if !ok {
+		respondWithError(w, http.StatusNotFound, "pending transaction not found")
+		return
+	}
+	if tx.Status != models.PendingAwaitingSignature {
+		respondWithError(w, http.StatusConflict, fmt.Sprintf("pending transaction is %s", tx.Status))
+		return
+	}
+
+	networkConfig, ok := s.Config.Config.Networks[tx.Network]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, "invalid network")
+		return
+	}
+
+	signed, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 64<<10))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := pendingtx.CheckSigned(tx, signed); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.broadcastPending(r.Context(), w, tx, signed, networkConfig.RPCEndpoint)
+}
+
+func (s *Server) handleSubmitSignature(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+	signer := vars["signer"]
+
+	if err := validate.PendingID(id); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := validate.Address(signer); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid signer address format")
+		return
+	}
+
+	s.expirePending()
+	tx, ok := s.Pending.Get(id)
+	if !ok {
+		respondWithError(w, http.StatusNotFound, "pending transaction not found")
+		return
+	}
+
+	networkConfig, ok := s.Config.Config.Networks[tx.Network]
+	if !ok || !networkConfig.MultisigBank() {
+		respondWithError(w, http.StatusBadRequest, "network bank is not a multisig")
+		return
+	}
+
+	signature, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 64<<10))
+	if err != nil || !json.Valid(signature) {
+		respondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	tx, err = s.Pending.AddSignature(id, signer, string(signature))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.Logger.Info("multisig signature received",
+		"id", id, "signer", signer, "have", len(tx.Signatures), "threshold", tx.Threshold)
+
+	if len(tx.Signatures) < tx.Threshold {
+		respondWithJSON(w, http.StatusOK, tx)
+		return
+	}
+
+	signed, err := s.Executor.Multisign(r.Context(), tx, networkConfig.Multisig.Key, networkConfig.RPCEndpoint)
+	if err != nil {
+		s.Logger.Error("multisign error", "id", id, "error", err)
+		respondWithError(w, http.StatusInternalServerError, "failed to combine multisig signatures")
+		return
+	}
+
+	if err := pendingtx.CheckSigned(tx, signed); err != nil {
+		s.Logger.Error("combined multisig tx does not match pending tx", "id", id, "error", err)
+		respondWithError(w, http.StatusInternalServerError, "failed to combine multisig signatures")
+		return
+	}
+
+	s.broadcastPending(r.Context(), w, tx, signed, networkConfig.RPCEndpoint)
+}
+
+// expirePending marks pending transactions past their TTL as expired, so a
+// signature arriving after it is refused even before the tracker runs.
+func (s *Server) expirePending() {
+	if _, err := s.Pending.Expire(time.Now()); err != nil {
+		s.Logger.Error("failed to persist expired pending transactions", "error", err)
+	}
+}
+
+// broadcastPending broadcasts a fully signed pending transaction and records
+// it as broadcast so the tracker can follow it to confirmation.
+func (s *Server) broadcastPending(ctx context.Context, w http.ResponseWriter, tx models.PendingTx, signed []byte, rpcEndpoint string) {
+	s.Logger.Info("broadcasting signed transaction", "id", tx.ID, "network", tx.Network)
+
+	result, err := s.Executor.BroadcastSigned(ctx, signed, tx.Network, rpcEndpoint)
+	if err != nil {
+		s.Logger.Error("broadcast error", "error", err)
+		respondWithError(w, http.StatusInternalServerError, "broadcast failed")
+		return
+	}
+	if !result.Success {
+		respondWithJSON(w, http.StatusOK, result)
+		return
+	}
+
+	if _, err := s.Pending.Update(tx.ID, func(p *models.PendingTx) error {
+		now := time.Now()
+		p.Status = models.PendingBroadcast
+		p.TxHash = result.TxHash
+		p.BroadcastAt = &now
+		return nil
+	}); err != nil {
+		s.Logger.Error("failed to record broadcast", "id", tx.ID, "error", err)
+	}
+
+	s.AppCache.Delete(tx.Network)
+	s.BankCache.Delete(tx.Network)
+
+	result.PendingID = tx.ID
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleDeletePendingTx(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if err := validate.PendingID(id); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tx, ok := s.Pending.Get(id)
+	if !ok {
+		respondWithError(w, http.StatusNotFound, "pending transaction not found")
+		return
+	}
+	if tx.Status == models.PendingBroadcast {
+		respondWithError(w, http.StatusConflict, "pending transaction has already been broadcast")
+		return
+	}
+
+	if err := s.Pending.Delete(id); err != nil {
+		s.Logger.Error("failed to delete pending transaction", "error", err)
+		respondWithError(w, http.StatusInternalServerError, "failed to delete pending transaction")
+		return
+	}
+
+	s.Logger.Info("pending transaction deleted", "id", id)
+
+	respondWithJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+func (s *Server) handleGetNetworks(w http.ResponseWriter, r *http.Request) {
 	networks := make([]string, 0, len(s.Config.Config.Networks))
 	for name := range s.Config.Config.Networks {
 		networks = append(networks, name)
 	}
-	respondWithJSON(w, http.StatusOK, networks)
+
+	switch r.URL.Query().Get("version") {
+	case "", "1":
+		respondWithJSON(w, http.StatusOK, networks)
+	case "2":
+		// Heights come from the endpoint probes, so this never waits on
+		// the chain.
+		sort.Strings(networks)
+		resp := models.NetworksResponse{
+			Version:  models.NetworksResponseVersion,
+			Networks: make([]models.NetworkStatus, 0, len(networks)),
+		}
+		for _, name := range networks {
+			status := models.NetworkStatus{Name: name}
+			if s.Endpoints != nil {
+				status, _ = s.Endpoints.ChainStatus(name)
+			}
+			resp.Networks = append(resp.Networks, status)
+		}
+		respondWithJSON(w, http.StatusOK, resp)
+	default:
+		respondWithError(w, http.StatusBadRequest, "unsupported version")
+	}
+}
+
+func (s *Server) handleGetNetworkEndpoints(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+	if _, ok := s.Config.Config.Networks[name]; !ok {
+		respondWithError(w, http.StatusNotFound, "network not found")
+		return
+	}
+	if s.Endpoints == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "endpoint tracking is not enabled")
+		return
+	}
+
+	status, _ := s.Endpoints.Status(name)
+	respondWithJSON(w, http.StatusOK, status)
 }
 
 func (s *Server) handleGetConfig(w http.ResponseWriter, _ *http.Request) {
@@ -467,6 +1390,27 @@ func (s *Server) handleFrontend(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	// A network with no healthy API or RPC endpoint degrades SAM but doesn't
+	// make it unhealthy: restarting it won't bring the endpoints back.
+	status := "healthy"
+	var endpoints []pocket.NetworkEndpoints
+	if s.Endpoints != nil {
+		endpoints = s.Endpoints.All()
+		if !s.Endpoints.Healthy() {
+			status = "degraded"
+		}
+	}
+
+	if s.Simulated {
+		respondWithJSON(w, http.StatusOK, map[string]interface{}{
+			"status":    status,
+			"mode":      "simulate",
+			"networks":  len(s.Config.Config.Networks),
+			"endpoints": endpoints,
+		})
+		return
+	}
+
 	_, err := exec.LookPath("pocketd")
 	if err != nil {
 		respondWithJSON(w, http.StatusServiceUnavailable, map[string]interface{}{
@@ -476,11 +1420,22 @@ func (s *Server) handleHealth(w http.ResponseWriter, _ *http.Request) {
 		return
 	}
 
+	if keyring := s.Executor.KeyringStatus(); keyring != nil && !keyring.OK {
+		respondWithJSON(w, http.StatusServiceUnavailable, map[string]interface{}{
+			"status":  "unhealthy",
+			"error":   keyring.Error,
+			"keyring": keyring,
+		})
+		return
+	}
+
 	respondWithJSON(w, http.StatusOK, map[string]interface{}{
-		"status":   "healthy",
-		"pocketd":  "available",
-		"networks": len(s.Config.Config.Networks),
-		"method":   "direct_api",
+		"status":    status,
+		"pocketd":   "available",
+		"keyring":   s.Executor.KeyringStatus(),
+		"networks":  len(s.Config.Config.Networks),
+		"endpoints": endpoints,
+		"method":    "direct_api",
 	})
 }
 
@@ -2,12 +2,17 @@ package handler
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -16,8 +21,11 @@ import (
 	"github.com/pokt-network/sam/internal/autotopup"
 	"github.com/pokt-network/sam/internal/cache"
 	"github.com/pokt-network/sam/internal/config"
+	"github.com/pokt-network/sam/internal/history"
 	"github.com/pokt-network/sam/internal/models"
+	"github.com/pokt-network/sam/internal/pendingtx"
 	"github.com/pokt-network/sam/internal/pocket"
+	"github.com/pokt-network/sam/internal/pocket/fake"
 )
 
 func newTestServer(t *testing.T) *Server {
@@ -47,22 +55,44 @@ func newTestServer(t *testing.T) *Server {
 		t.Fatal(err)
 	}
 
+	sweepStore, err := autotopup.NewSweepStore(filepath.Join(t.TempDir(), "sweep.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pendingStore, err := pendingtx.NewStore(filepath.Join(t.TempDir(), "pending.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	historyStore, err := history.NewStore(filepath.Join(t.TempDir(), "history.json"), history.DefaultPolicy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	client := pocket.NewClient(logger)
-	executor := pocket.NewExecutor(cfg, client, logger)
-	appCache := cache.New[[]models.Application](1 * time.Minute)
+	executor := pocket.NewExecutor(cfg, client, pendingStore, logger)
+	appCache := cache.New[models.ApplicationsResponse](1 * time.Minute)
 	bankCache := cache.New[models.BankAccount](1 * time.Minute)
 
-	worker := autotopup.NewWorker(store, cfg, client, executor, appCache, bankCache, logger)
+	worker := autotopup.NewWorker(store, sweepStore, cfg, client, executor, pendingStore, appCache, bankCache, logger)
 
 	return &Server{
-		Config:    cfg,
-		Client:    client,
-		Executor:  executor,
-		AppCache:  appCache,
-		BankCache: bankCache,
-		AutoTopUp: store,
-		Worker:    worker,
-		Logger:    logger,
+		Config:       cfg,
+		Client:       client,
+		Executor:     executor,
+		AppCache:     appCache,
+		LastGood:     cache.New[models.Application](time.Hour),
+		BankCache:    bankCache,
+		Discovery:    cache.New[models.DiscoveryReport](time.Hour),
+		ServiceCache: cache.New[[]models.ServiceInfo](time.Hour),
+		ParamsCache:  cache.New[models.ChainParams](time.Hour),
+		AutoTopUp:    store,
+		Sweeps:       sweepStore,
+		Pending:      pendingStore,
+		History:      historyStore,
+		Worker:       worker,
+		Logger:       logger,
 	}
 }
 
@@ -363,6 +393,362 @@ func TestHandleGetAutoTopUpEvents(t *testing.T) {
 	}
 }
 
+func TestHandleGetNetworkEndpoints(t *testing.T) {
+	srv := newTestServer(t)
+	srv.Endpoints = pocket.NewEndpoints(srv.Config)
+	router := setupRouter(srv)
+
+	req := httptest.NewRequest("GET", "/api/networks/pocket/endpoints", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	var resp pocket.NetworkEndpoints
+	json.NewDecoder(w.Body).Decode(&resp)
+	if resp.Network != "pocket" || len(resp.API) != 1 || len(resp.RPC) != 1 || !resp.API[0].Active {
+		t.Errorf("response = %+v", resp)
+	}
+
+	req = httptest.NewRequest("GET", "/api/networks/nonexistent/endpoints", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("unknown network status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestHandleSetSweepPolicy_Valid(t *testing.T) {
+	srv := newTestServer(t)
+	router := setupRouter(srv)
+
+	addr := "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	body := `{"enabled":true,"floor":10}`
+	req := httptest.NewRequest("PUT", "/api/applications/"+addr+"/sweep/policy?network=pocket", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d; body = %s", w.Code, http.StatusOK, w.Body.String())
+	}
+
+	got, ok := srv.Sweeps.Get("pocket", addr)
+	if !ok {
+		t.Fatal("sweep policy should be stored")
+	}
+	if !got.Enabled || got.Floor != 10_000_000 {
+		t.Errorf("policy = %+v, want enabled with floor 10000000", got)
+	}
+}
+
+func TestHandleSetSweepPolicy_NegativeFloor(t *testing.T) {
+	srv := newTestServer(t)
+	router := setupRouter(srv)
+
+	addr := "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	body := `{"enabled":true,"floor":-1}`
+	req := httptest.NewRequest("PUT", "/api/applications/"+addr+"/sweep/policy?network=pocket", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestHandleDeleteSweepPolicy(t *testing.T) {
+	srv := newTestServer(t)
+	router := setupRouter(srv)
+
+	addr := "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	srv.Sweeps.Set("pocket", addr, models.SweepConfig{Enabled: true, Floor: 1000})
+
+	req := httptest.NewRequest("DELETE", "/api/applications/"+addr+"/sweep/policy?network=pocket", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+
+	if _, ok := srv.Sweeps.Get("pocket", addr); ok {
+		t.Error("sweep policy should be deleted")
+	}
+}
+
+func TestHandleSweep_InvalidFloor(t *testing.T) {
+	srv := newTestServer(t)
+	router := setupRouter(srv)
+
+	addr := "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	req := httptest.NewRequest("POST", "/api/applications/"+addr+"/sweep?network=pocket", bytes.NewBufferString(`{"floor":-5}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestHandleSweep_InvalidNetwork(t *testing.T) {
+	srv := newTestServer(t)
+	router := setupRouter(srv)
+
+	addr := "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	req := httptest.NewRequest("POST", "/api/applications/"+addr+"/sweep?network=nonexistent", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestHandleSweep_UnconfiguredApplication(t *testing.T) {
+	srv := newTestServer(t)
+	router := setupRouter(srv)
+
+	addr := "pokt1cccccccccccccccccccccccccccccccccccccc"
+	for _, req := range []*http.Request{
+		httptest.NewRequest("POST", "/api/applications/"+addr+"/sweep?network=pocket", nil),
+		httptest.NewRequest("PUT", "/api/applications/"+addr+"/sweep/policy?network=pocket", bytes.NewBufferString(`{"enabled":true,"floor":10}`)),
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s %s status = %d, want %d", req.Method, req.URL.Path, w.Code, http.StatusBadRequest)
+		}
+	}
+	if _, ok := srv.Sweeps.Get("pocket", addr); ok {
+		t.Error("sweep policy stored for an unconfigured app")
+	}
+}
+
+func TestHandlePendingTx_DownloadAndReject(t *testing.T) {
+	srv := newTestServer(t)
+	router := setupRouter(srv)
+
+	unsigned := `{"body":{"messages":[],"memo":""},"auth_info":{},"signatures":[]}`
+	tx, err := srv.Pending.Add(models.PendingTx{Network: "pocket", Kind: "fund", UnsignedTx: unsigned})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest("GET", "/api/pending/"+tx.ID+"/unsigned", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if w.Body.String() != unsigned {
+		t.Errorf("body = %s, want unsigned tx", w.Body.String())
+	}
+
+	// Uploading the unsigned tx back (no signatures) must be rejected.
+	req = httptest.NewRequest("POST", "/api/pending/"+tx.ID+"/signed", bytes.NewBufferString(unsigned))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestHandleSubmitSignature_NotMultisig(t *testing.T) {
+	srv := newTestServer(t)
+	router := setupRouter(srv)
+
+	tx, err := srv.Pending.Add(models.PendingTx{Network: "pocket", Kind: "fund", UnsignedTx: `{"body":{}}`})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer := "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	req := httptest.NewRequest("POST", "/api/pending/"+tx.ID+"/signatures/"+signer, bytes.NewBufferString(`{}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestHandleSubmitSignature_RecordsPartial(t *testing.T) {
+	srv := newTestServer(t)
+	router := setupRouter(srv)
+
+	signerA := "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	signerC := "pokt1cccccccccccccccccccccccccccccccccccccc"
+	netCfg := srv.Config.Config.Networks["pocket"]
+	netCfg.BankSigning = config.BankSigningMultisig
+	netCfg.Multisig = config.MultisigConfig{Key: "bank-multisig", Threshold: 2, Signers: []string{signerA, signerC}}
+	srv.Config.Config.Networks["pocket"] = netCfg
+
+	tx, err := srv.Pending.Add(models.PendingTx{
+		Network:    "pocket",
+		Kind:       "fund",
+		UnsignedTx: `{"body":{}}`,
+		Threshold:  2,
+		Signers:    []string{signerA, signerC},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest("POST", "/api/pending/"+tx.ID+"/signatures/"+signerA, bytes.NewBufferString(`{"signatures":[]}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d; body = %s", w.Code, http.StatusOK, w.Body.String())
+	}
+
+	var got models.PendingTx
+	json.NewDecoder(w.Body).Decode(&got)
+	if got.Status != models.PendingAwaitingSignature {
+		t.Errorf("Status = %q, want %q below threshold", got.Status, models.PendingAwaitingSignature)
+	}
+	if len(got.MissingSigners) != 1 || got.MissingSigners[0] != signerC {
+		t.Errorf("MissingSigners = %v, want [%s]", got.MissingSigners, signerC)
+	}
+}
+
+func TestHandlePendingTx_NotFound(t *testing.T) {
+	srv := newTestServer(t)
+	router := setupRouter(srv)
+
+	req := httptest.NewRequest("GET", "/api/pending/0123456789abcdef", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+
+	req = httptest.NewRequest("GET", "/api/pending/not-an-id", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestHandleGrantStake_Validation(t *testing.T) {
+	srv := newTestServer(t)
+	router := setupRouter(srv)
+
+	addr := "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	tests := []struct {
+		name string
+		path string
+		body string
+	}{
+		{"invalid address", "/api/applications/badaddr/authz?network=pocket", ""},
+		{"invalid network", "/api/applications/" + addr + "/authz?network=nonexistent", ""},
+		{"negative expiration", "/api/applications/" + addr + "/authz?network=pocket", `{"expiration_days":-1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", tt.path, bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+			}
+		})
+	}
+}
+
+func TestHandleSetFeeGrant_Validation(t *testing.T) {
+	srv := newTestServer(t)
+	router := setupRouter(srv)
+
+	addr := "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"zero spend limit", `{"spend_limit":0}`},
+		{"negative spend limit", `{"spend_limit":-1}`},
+		{"negative expiration", `{"spend_limit":1,"expiration_days":-1}`},
+		{"invalid body", `not json`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("PUT", "/api/applications/"+addr+"/feegrant?network=pocket", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+			}
+		})
+	}
+}
+
+func TestHandleSetFeeGrant_OfflineBank(t *testing.T) {
+	srv := newTestServer(t)
+	router := setupRouter(srv)
+
+	netCfg := srv.Config.Config.Networks["pocket"]
+	netCfg.BankSigning = config.BankSigningOffline
+	srv.Config.Config.Networks["pocket"] = netCfg
+
+	// pocketd only generates: it records its arguments and prints a tx.
+	dir := t.TempDir()
+	argsFile := filepath.Join(dir, "args")
+	script := "#!/bin/sh\necho \"$@\" >> " + argsFile + "\necho '{\"body\":{\"messages\":[]},\"auth_info\":{},\"signatures\":[]}'\n"
+	if err := os.WriteFile(filepath.Join(dir, "pocketd"), []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+	srv.Executor.(*pocket.Executor).Binary = filepath.Join(dir, "pocketd")
+
+	addr := "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	for _, tt := range []struct {
+		method, body, kind string
+	}{
+		{"PUT", `{"spend_limit":1}`, "feegrant grant"},
+		{"DELETE", "", "feegrant revoke"},
+	} {
+		req := httptest.NewRequest(tt.method, "/api/applications/"+addr+"/feegrant?network=pocket", bytes.NewBufferString(tt.body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var resp models.TransactionResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		if w.Code != http.StatusOK || resp.PendingID == "" {
+			t.Fatalf("%s status = %d, body = %s; want a pending transaction", tt.method, w.Code, w.Body.String())
+		}
+		if tx, ok := srv.Pending.Get(resp.PendingID); !ok || tx.Kind != tt.kind || tx.To != addr {
+			t.Errorf("%s pending tx = %+v, want kind %q", tt.method, tx, tt.kind)
+		}
+	}
+
+	// Nothing was signed with a local bank key.
+	args, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(args), "--from") || strings.Count(string(args), "--generate-only") != 2 {
+		t.Errorf("pocketd args = %s, want two --generate-only commands without --from", args)
+	}
+}
+
 func TestHandleGetServices_InvalidNetwork(t *testing.T) {
 	srv := newTestServer(t)
 	router := setupRouter(srv)
@@ -395,6 +781,27 @@ func TestHandleGetNetworks(t *testing.T) {
 	if len(networks) != 1 || networks[0] != "pocket" {
 		t.Errorf("networks = %v, want [pocket]", networks)
 	}
+
+	srv.Endpoints = pocket.NewEndpoints(srv.Config)
+	req = httptest.NewRequest("GET", "/api/networks?version=2", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var resp models.NetworksResponse
+	json.NewDecoder(w.Body).Decode(&resp)
+	if resp.Version != models.NetworksResponseVersion || len(resp.Networks) != 1 || resp.Networks[0].Name != "pocket" {
+		t.Errorf("v2 response = %+v", resp)
+	}
+	if resp.Networks[0].Height != 0 || resp.Networks[0].Stalled {
+		t.Errorf("unprobed network status = %+v, want no height and not stalled", resp.Networks[0])
+	}
+
+	req = httptest.NewRequest("GET", "/api/networks?version=9", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("unsupported version status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
 }
 
 func TestHandleGetConfig(t *testing.T) {
@@ -435,3 +842,723 @@ func TestDefaultNetworkFallback(t *testing.T) {
 		t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
 	}
 }
+
+// newFakeChainServer returns a test server whose reads and transactions go
+// to an in-memory chain instead of the Pocket API and pocketd.
+func newFakeChainServer(t *testing.T) (*Server, *fake.Chain) {
+	t.Helper()
+
+	srv := newTestServer(t)
+	chain := fake.New()
+	srv.Client = chain
+	srv.Executor = chain
+	srv.Worker.Client = chain
+	srv.Worker.Executor = chain
+	srv.Worker.PollInterval = time.Millisecond
+	return srv, chain
+}
+
+func TestHandleStakeNewApplication_FakeChain(t *testing.T) {
+	srv, chain := newFakeChainServer(t)
+	router := setupRouter(srv)
+
+	const app = "pokt1cccccccccccccccccccccccccccccccccccccc"
+	chain.AddService("anvil", "Anvil")
+	chain.SetBalance(app, 200_000_000)
+
+	body := `{"address":"` + app + `","service_id":"anvil","amount":100}`
+	req := httptest.NewRequest("POST", "/api/applications/stake?network=pocket", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	if got := chain.Stake(app); got != 100_000_000 {
+		t.Errorf("stake = %d, want 100000000", got)
+	}
+	if apps := srv.Config.Config.Networks["pocket"].Applications; apps[len(apps)-1] != app {
+		t.Errorf("staked app not added to config: %v", apps)
+	}
+}
+
+func TestHandleNetworkParams_MinStake(t *testing.T) {
+	srv, chain := newFakeChainServer(t)
+	router := setupRouter(srv)
+
+	const (
+		staked = "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+		fresh  = "pokt1cccccccccccccccccccccccccccccccccccccc"
+	)
+	chain.SetMinStake(1_000_000)
+	chain.AddService("anvil", "Anvil")
+	chain.SetApplication(staked, "anvil", 600_000)
+	chain.SetBalance(staked, 100_000_000)
+	chain.SetBalance(fresh, 100_000_000)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do("GET", "/api/networks/pocket/params", "")
+	var params models.ChainParams
+	json.NewDecoder(w.Body).Decode(&params)
+	if w.Code != http.StatusOK || params.Application.MinStake != 1_000_000 || params.Shared.NumBlocksPerSession == 0 || params.Network != "pocket" {
+		t.Fatalf("params: status = %d, params = %+v", w.Code, params)
+	}
+	if w := do("GET", "/api/networks/nope/params", ""); w.Code != http.StatusNotFound {
+		t.Errorf("unknown network status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{"stake below min", "POST", "/api/applications/stake?network=pocket", `{"address":"` + fresh + `","service_id":"anvil","amount":"0.5"}`, http.StatusBadRequest},
+		{"upstake leaving stake below min", "POST", "/api/applications/" + staked + "/upstake?network=pocket", `{"amount":"0.1"}`, http.StatusBadRequest},
+		{"upstake reaching min", "POST", "/api/applications/" + staked + "/upstake?network=pocket", `{"amount":"0.4"}`, http.StatusOK},
+		{"trigger below min", "PUT", "/api/applications/" + staked + "/autotopup?network=pocket", `{"enabled":true,"trigger_threshold":"0.5","target_amount":5}`, http.StatusBadRequest},
+		{"trigger at min", "PUT", "/api/applications/" + staked + "/autotopup?network=pocket", `{"enabled":true,"trigger_threshold":1,"target_amount":5}`, http.StatusOK},
+		{"stake at min", "POST", "/api/applications/stake?network=pocket", `{"address":"` + fresh + `","service_id":"anvil","amount":1}`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := do(tt.method, tt.path, tt.body)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusBadRequest && !strings.Contains(w.Body.String(), "minimum application stake of 1 POKT") {
+				t.Errorf("body = %s, want the minimum stake", w.Body.String())
+			}
+		})
+	}
+	if got := chain.Stake(staked); got != 1_000_000 {
+		t.Errorf("stake = %d, want 1000000", got)
+	}
+}
+
+func TestHandleFundAndUpstake_FakeChain(t *testing.T) {
+	srv, chain := newFakeChainServer(t)
+	router := setupRouter(srv)
+
+	const app = "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	bank := srv.Config.Config.Networks["pocket"].Bank
+	chain.SetBalance(bank, 100_000_000)
+	chain.SetApplication(app, "anvil", 1_000_000)
+	chain.SetBalance(app, chain.Fee) // pays the upstake fee
+
+	for _, op := range []string{"fund", "upstake"} {
+		req := httptest.NewRequest("POST", "/api/applications/"+app+"/"+op+"?network=pocket", bytes.NewBufferString(`{"amount":5}`))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var resp models.TransactionResponse
+		json.NewDecoder(w.Body).Decode(&resp)
+		if w.Code != http.StatusOK || !resp.Success || resp.TxHash == "" {
+			t.Fatalf("%s: status = %d, resp = %+v", op, w.Code, resp)
+		}
+	}
+
+	if got := chain.Stake(app); got != 6_000_000 {
+		t.Errorf("stake = %d, want 6000000", got)
+	}
+	if got := chain.Balance(app); got != 0 {
+		t.Errorf("app balance = %d", got)
+	}
+}
+
+func TestHandleFund_DecimalStringAmounts(t *testing.T) {
+	srv, chain := newFakeChainServer(t)
+	router := setupRouter(srv)
+
+	const app = "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	bank := srv.Config.Config.Networks["pocket"].Bank
+	chain.SetBalance(bank, 100_000_000)
+	chain.SetApplication(app, "anvil", 1_000_000)
+
+	fund := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/applications/"+app+"/fund?network=pocket", bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	for _, body := range []string{`{"amount":"1.234567"}`, `{"amount":"1234567upokt"}`, `{"amount":1.234567}`} {
+		if w := fund(body); w.Code != http.StatusOK {
+			t.Fatalf("fund %s: status = %d, body = %s", body, w.Code, w.Body.String())
+		}
+	}
+	if got := chain.Balance(app); got != 3*1_234_567 {
+		t.Errorf("app balance = %d, want %d", got, 3*1_234_567)
+	}
+
+	for _, body := range []string{`{"amount":"1.2345678"}`, `{"amount":"1e3"}`, `{"amount":"1.5upokt"}`, `{"amount":"99999999999999999999"}`} {
+		if w := fund(body); w.Code != http.StatusBadRequest {
+			t.Errorf("fund %s: status = %d, want %d", body, w.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestHandleGetApplications_StringAmounts(t *testing.T) {
+	srv, chain := newFakeChainServer(t)
+	router := setupRouter(srv)
+
+	const app = "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	chain.SetApplication(app, "anvil", 5_000_001)
+	chain.SetBalance(srv.Config.Config.Networks["pocket"].Bank, 7_000_000)
+
+	get := func(path string) map[string]any {
+		t.Helper()
+		req := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("GET %s: status = %d, body = %s", path, w.Code, w.Body.String())
+		}
+		var body map[string]any
+		json.NewDecoder(w.Body).Decode(&body)
+		return body
+	}
+
+	list := get("/api/applications?network=pocket&refresh=true&version=3")
+	apps, _ := list["applications"].([]any)
+	if list["version"] != float64(models.AmountsVersion) || len(apps) != 1 {
+		t.Fatalf("v3 response = %+v", list)
+	}
+	if stake := apps[0].(map[string]any)["stake"]; stake != "5000001upokt" {
+		t.Errorf("v3 list stake = %v, want \"5000001upokt\"", stake)
+	}
+
+	if stake := get("/api/applications/" + app + "?network=pocket&version=3")["stake"]; stake != "5000001upokt" {
+		t.Errorf("v3 app stake = %v, want \"5000001upokt\"", stake)
+	}
+	if stake := get("/api/applications/" + app + "?network=pocket")["stake"]; stake != float64(5_000_001) {
+		t.Errorf("v1 app stake = %v, want 5000001", stake)
+	}
+
+	if balance := get("/api/bank?network=pocket&version=3")["balance"]; balance != "7000000upokt" {
+		t.Errorf("v3 bank balance = %v, want \"7000000upokt\"", balance)
+	}
+
+	// A network with another base denom writes and reads amounts in it.
+	netCfg := srv.Config.Config.Networks["pocket"]
+	netCfg.Denom = "ustake"
+	srv.Config.Config.Networks["pocket"] = netCfg
+	if stake := get("/api/applications/" + app + "?network=pocket&version=3")["stake"]; stake != "5000001ustake" {
+		t.Errorf("v3 app stake = %v, want \"5000001ustake\"", stake)
+	}
+	for body, want := range map[string]int{`{"amount":"1000000ustake"}`: http.StatusOK, `{"amount":"1000000upokt"}`: http.StatusBadRequest} {
+		req := httptest.NewRequest("POST", "/api/applications/"+app+"/fund?network=pocket", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != want {
+			t.Errorf("fund %s: status = %d, want %d (body %s)", body, w.Code, want, w.Body.String())
+		}
+	}
+}
+
+func TestHandleGetApplications_Height(t *testing.T) {
+	srv, chain := newFakeChainServer(t)
+	router := setupRouter(srv)
+
+	const app = "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	chain.SetApplication(app, "anvil", 5_000_000)
+	chain.NextBlock()
+	height := chain.NextBlock()
+
+	req := httptest.NewRequest("GET", "/api/applications?network=pocket&version=2&refresh=true", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var resp models.ApplicationsResponse
+	json.NewDecoder(w.Body).Decode(&resp)
+	if resp.Height != height || len(resp.Applications) != 1 || resp.Applications[0].Height != height {
+		t.Errorf("heights = %d / %+v, want %d", resp.Height, resp.Applications, height)
+	}
+
+	chain.NextBlock()
+	req = httptest.NewRequest("GET", "/api/applications/"+app+"?network=pocket", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var single models.Application
+	json.NewDecoder(w.Body).Decode(&single)
+	if single.Height != height+1 {
+		t.Errorf("app height = %d, want %d", single.Height, height+1)
+	}
+}
+
+func TestHandleUpstake_FakeChainFailure(t *testing.T) {
+	srv, chain := newFakeChainServer(t)
+	router := setupRouter(srv)
+
+	const app = "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	chain.SetApplication(app, "anvil", 1_000_000)
+	// No liquid balance: the upstake can't pay for itself.
+
+	req := httptest.NewRequest("POST", "/api/applications/"+app+"/upstake?network=pocket", bytes.NewBufferString(`{"amount":5}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var resp models.TransactionResponse
+	json.NewDecoder(w.Body).Decode(&resp)
+	if resp.Success {
+		t.Errorf("upstake without balance succeeded: %+v", resp)
+	}
+	if got := chain.Stake(app); got != 1_000_000 {
+		t.Errorf("stake = %d, want unchanged", got)
+	}
+}
+
+func TestHandleGetApplications_StaleAndErrors(t *testing.T) {
+	srv, chain := newFakeChainServer(t)
+	router := setupRouter(srv)
+
+	const (
+		app     = "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+		missing = "pokt1dddddddddddddddddddddddddddddddddddddd"
+	)
+	chain.SetApplication(app, "anvil", 5_000_000)
+	srv.Config.AddApplicationAddress("pocket", missing)
+
+	get := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/applications?network=pocket&refresh=true"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// First fetch: the app succeeds, the unstaked address is an error.
+	w := get("&version=2")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	var resp models.ApplicationsResponse
+	json.NewDecoder(w.Body).Decode(&resp)
+	if resp.Version != models.ApplicationsResponseVersion || len(resp.Applications) != 1 || resp.Applications[0].Stale {
+		t.Fatalf("first response = %+v", resp)
+	}
+	if len(resp.Errors) != 1 || resp.Errors[0].Address != missing || resp.Errors[0].Stale {
+		t.Errorf("errors = %+v, want one non-stale error for %s", resp.Errors, missing)
+	}
+
+	// The app's query now fails: its last known value comes back stale.
+	chain.FailQuery(app, "API returned status 502")
+	resp = models.ApplicationsResponse{}
+	json.NewDecoder(get("&version=2").Body).Decode(&resp)
+	if len(resp.Applications) != 1 || !resp.Applications[0].Stale || resp.Applications[0].FetchedAt == nil {
+		t.Fatalf("applications = %+v, want the stale app with fetched_at", resp.Applications)
+	}
+	if resp.Applications[0].Stake != 5_000_000 {
+		t.Errorf("stale stake = %d, want 5000000", resp.Applications[0].Stake)
+	}
+	if len(resp.Errors) != 2 {
+		t.Errorf("got %d errors, want 2", len(resp.Errors))
+	}
+
+	// Version 1 stays a bare array.
+	var apps []models.Application
+	if err := json.NewDecoder(get("").Body).Decode(&apps); err != nil || len(apps) != 1 || !apps[0].Stale {
+		t.Errorf("v1 response = %+v, err %v", apps, err)
+	}
+
+	if w := get("&version=4"); w.Code != http.StatusBadRequest {
+		t.Errorf("unsupported version status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+// countingChain counts application list queries and slows them down so
+// concurrent requests overlap.
+type countingChain struct {
+	*fake.Chain
+	queries atomic.Int32
+}
+
+func (c *countingChain) ListApplications(ctx context.Context, apiEndpoint, network, gateway string) ([]*models.Application, error) {
+	c.queries.Add(1)
+	time.Sleep(50 * time.Millisecond)
+	return c.Chain.ListApplications(ctx, apiEndpoint, network, gateway)
+}
+
+// blockingChain holds ListApplications until release is closed.
+type blockingChain struct {
+	*fake.Chain
+	started chan struct{}
+	release chan struct{}
+}
+
+func (c *blockingChain) ListApplications(ctx context.Context, apiEndpoint, network, gateway string) ([]*models.Application, error) {
+	c.started <- struct{}{}
+	<-c.release
+	return c.Chain.ListApplications(ctx, apiEndpoint, network, gateway)
+}
+
+func TestRefreshApplications_DropsResultInvalidatedMidFlight(t *testing.T) {
+	srv, chain := newFakeChainServer(t)
+	chain.SetApplication("pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "anvil", 5_000_000)
+	blocking := &blockingChain{Chain: chain, started: make(chan struct{}, 2), release: make(chan struct{})}
+	srv.Client = blocking
+	netCfg := srv.Config.Config.Networks["pocket"]
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		srv.refreshApplications("pocket", netCfg)
+	}()
+	<-blocking.started
+
+	// A transaction lands while the fetch is in flight.
+	srv.AppCache.Delete("pocket")
+	close(blocking.release)
+	<-done
+
+	if _, ok := srv.AppCache.Get("pocket"); ok {
+		t.Error("a fetch started before the invalidation was cached")
+	}
+
+	// A refresh after the invalidation is cached.
+	srv.refreshApplications("pocket", netCfg)
+	if _, ok := srv.AppCache.Get("pocket"); !ok {
+		t.Error("a fetch started after the invalidation was not cached")
+	}
+}
+
+// grantCountingChain counts per-app grant queries.
+type grantCountingChain struct {
+	*fake.Chain
+	queries atomic.Int32
+}
+
+func (c *grantCountingChain) QueryStakeGrant(ctx context.Context, granter, grantee, apiEndpoint string) (*models.AuthzGrant, error) {
+	c.queries.Add(1)
+	return c.Chain.QueryStakeGrant(ctx, granter, grantee, apiEndpoint)
+}
+
+func (c *grantCountingChain) QueryFeeAllowance(ctx context.Context, granter, grantee, apiEndpoint, denom string) (*models.FeeAllowance, error) {
+	c.queries.Add(1)
+	return c.Chain.QueryFeeAllowance(ctx, granter, grantee, apiEndpoint, denom)
+}
+
+func TestHandleGetApplications_ListsGrants(t *testing.T) {
+	srv, chain := newFakeChainServer(t)
+	const app = "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	const bank = "pokt1bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	const other = "pokt1cccccccccccccccccccccccccccccccccccccc"
+	chain.SetApplication(app, "anvil", 5_000_000)
+	chain.SetApplication(other, "anvil", 5_000_000)
+	chain.SetBalance(app, 1_000)
+	chain.SetBalance(bank, 1_000_000)
+	chain.GrantStakeAuthz(context.Background(), app, bank, "pocket", 0, "")
+	chain.GrantFeeAllowance(context.Background(), app, bank, "pocket", 100, 0, "")
+	netCfg := srv.Config.Config.Networks["pocket"]
+	netCfg.Applications = append(netCfg.Applications, other)
+	srv.Config.Config.Networks["pocket"] = netCfg
+	counting := &grantCountingChain{Chain: chain}
+	srv.Client = counting
+
+	resp := srv.refreshApplications("pocket", netCfg)
+	if len(resp.Applications) != 2 {
+		t.Fatalf("applications = %+v", resp.Applications)
+	}
+	for _, a := range resp.Applications {
+		granted := a.Address == app
+		if (a.StakeGrant != nil) != granted || (a.FeeAllowance != nil) != granted {
+			t.Errorf("%s grants = %+v, %+v; want granted %v", a.Address, a.StakeGrant, a.FeeAllowance, granted)
+		}
+	}
+	if got := counting.queries.Load(); got != 0 {
+		t.Errorf("got %d per-app grant queries, want none", got)
+	}
+}
+
+func TestHandleGetApplications_DeduplicatesConcurrentFetches(t *testing.T) {
+	srv, chain := newFakeChainServer(t)
+	chain.SetApplication("pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "anvil", 5_000_000)
+	counting := &countingChain{Chain: chain}
+	srv.Client = counting
+	router := setupRouter(srv)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := httptest.NewRequest("GET", "/api/applications?network=pocket&refresh=true", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != http.StatusOK {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := counting.queries.Load(); got != 1 {
+		t.Errorf("got %d application list queries for 10 concurrent requests, want 1", got)
+	}
+}
+
+func TestHandleGetApplications_StaleWhileRevalidate(t *testing.T) {
+	srv, chain := newFakeChainServer(t)
+	const app = "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	chain.SetApplication(app, "anvil", 5_000_000)
+	srv.AppCache = cache.New[models.ApplicationsResponse](10 * time.Millisecond)
+	router := setupRouter(srv)
+
+	get := func() []models.Application {
+		req := httptest.NewRequest("GET", "/api/applications?network=pocket", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var apps []models.Application
+		json.NewDecoder(w.Body).Decode(&apps)
+		return apps
+	}
+
+	if apps := get(); len(apps) != 1 || apps[0].Stake != 5_000_000 {
+		t.Fatalf("first response = %+v", apps)
+	}
+
+	// Once expired, the old value is served while a refresh runs.
+	chain.SetApplication(app, "anvil", 7_000_000)
+	time.Sleep(20 * time.Millisecond)
+	if apps := get(); len(apps) != 1 || apps[0].Stake != 5_000_000 {
+		t.Fatalf("expired response = %+v, want the cached stake", apps)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		resp, _, _ := srv.AppCache.GetStale("pocket")
+		if len(resp.Applications) == 1 && resp.Applications[0].Stake == 7_000_000 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("cache was not refreshed in the background")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestRefreshAll(t *testing.T) {
+	srv, chain := newFakeChainServer(t)
+	chain.SetApplication("pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "anvil", 5_000_000)
+	chain.SetBalance("pokt1bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", 9_000_000)
+
+	srv.RefreshAll()
+
+	if resp, ok := srv.AppCache.Get("pocket"); !ok || len(resp.Applications) != 1 {
+		t.Errorf("AppCache = %+v, %v; want one application", resp, ok)
+	}
+	if bank, ok := srv.BankCache.Get("pocket"); !ok || bank.Balance != 9_000_000 {
+		t.Errorf("BankCache = %+v, %v; want balance 9000000", bank, ok)
+	}
+}
+
+func TestOnChainEvent_InvalidatesCaches(t *testing.T) {
+	srv, chain := newFakeChainServer(t)
+	const app = "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	const bank = "pokt1bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	chain.SetApplication(app, "anvil", 5_000_000)
+	chain.SetBalance(bank, 9_000_000)
+	srv.RefreshAll()
+
+	srv.OnChainEvent(pocket.ChainEvent{Network: "pocket", Kind: pocket.EventBlock, Addresses: []string{app}})
+	if _, ok := srv.AppCache.Get("pocket"); ok {
+		t.Error("AppCache kept after an event touching an app")
+	}
+	if _, ok := srv.BankCache.Get("pocket"); !ok {
+		t.Error("BankCache dropped by an event not touching the bank")
+	}
+
+	srv.OnChainEvent(pocket.ChainEvent{Network: "pocket", Kind: pocket.EventTx, Addresses: []string{bank}})
+	if _, ok := srv.BankCache.Get("pocket"); ok {
+		t.Error("BankCache kept after an event touching the bank")
+	}
+}
+
+func TestHandleGetHistory(t *testing.T) {
+	srv, chain := newFakeChainServer(t)
+	router := setupRouter(srv)
+	const app = "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	chain.SetApplication(app, "anvil", 5_000_000)
+	chain.SetBalance("pokt1bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", 9_000_000)
+	srv.RefreshAll()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/applications/"+app+"/history?network=pocket&step=1h", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("app history status = %d: %s", w.Code, w.Body.String())
+	}
+	var appHistory models.AppHistoryResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &appHistory); err != nil {
+		t.Fatal(err)
+	}
+	if len(appHistory.Points) != 1 || appHistory.Points[0].Stake != 5_000_000 {
+		t.Errorf("app history points = %+v, want the refreshed stake", appHistory.Points)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/bank/history", nil))
+	var bankHistory models.BankHistoryResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &bankHistory); err != nil {
+		t.Fatal(err)
+	}
+	if w.Code != http.StatusOK || len(bankHistory.Points) != 1 || bankHistory.Points[0].Balance != 9_000_000 {
+		t.Errorf("bank history = %d %+v, want the refreshed balance", w.Code, bankHistory)
+	}
+
+	for _, query := range []string{"step=soon", "step=-1h", "from=yesterday", "from=2026-01-02T00:00:00Z&to=2026-01-01T00:00:00Z", "network=nope"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/api/applications/"+app+"/history?"+query, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("?%s status = %d, want %d", query, w.Code, http.StatusBadRequest)
+		}
+	}
+
+	// Without a history store the endpoints serve empty series.
+	srv.History = nil
+	srv.RefreshAll()
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/bank/history", nil))
+	if err := json.Unmarshal(w.Body.Bytes(), &bankHistory); err != nil {
+		t.Fatal(err)
+	}
+	if w.Code != http.StatusOK || bankHistory.Points == nil || len(bankHistory.Points) != 0 {
+		t.Errorf("bank history without a store = %d %+v, want empty points", w.Code, bankHistory)
+	}
+}
+
+// newDiscoveryServer returns a fake-chain server whose network has gateway
+// "pokt1gggg...". The tracked app pokt1aaaa... is delegated elsewhere;
+// pokt1cccc... and pokt1dddd... are delegated to the gateway but untracked.
+func newDiscoveryServer(t *testing.T) (*Server, *fake.Chain) {
+	t.Helper()
+	srv, chain := newFakeChainServer(t)
+
+	const gateway = "pokt1gggggggggggggggggggggggggggggggggggggg"
+	netCfg := srv.Config.Config.Networks["pocket"]
+	netCfg.Gateways = []string{gateway}
+	srv.Config.Config.Networks["pocket"] = netCfg
+
+	chain.SetApplication("pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "anvil", 5_000_000)
+	chain.SetGateway("pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "pokt1hhhhhhhhhhhhhhhhhhhhhhhhhhhhhhhhhhhhhh")
+	for _, app := range []string{"pokt1cccccccccccccccccccccccccccccccccccccc", "pokt1dddddddddddddddddddddddddddddddddddddd"} {
+		chain.SetApplication(app, "anvil", 1_000_000)
+		chain.SetGateway(app, gateway)
+	}
+	return srv, chain
+}
+
+func TestHandleGetDiscovery(t *testing.T) {
+	srv, _ := newDiscoveryServer(t)
+	router := setupRouter(srv)
+
+	req := httptest.NewRequest("GET", "/api/discovery?network=pocket", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	var report models.DiscoveryReport
+	if err := json.NewDecoder(w.Body).Decode(&report); err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Untracked) != 2 || report.Untracked[0].Address != "pokt1cccccccccccccccccccccccccccccccccccccc" {
+		t.Errorf("untracked = %+v, want pokt1cccc... and pokt1dddd...", report.Untracked)
+	}
+	if len(report.NotDelegated) != 1 || report.NotDelegated[0].Gateway != "pokt1hhhhhhhhhhhhhhhhhhhhhhhhhhhhhhhhhhhhhh" {
+		t.Errorf("not delegated = %+v, want pokt1aaaa... delegated to pokt1hhhh...", report.NotDelegated)
+	}
+	if _, ok := srv.Discovery.Get("pocket"); !ok {
+		t.Error("discovery report not cached")
+	}
+
+	// A network without gateways can't be discovered.
+	netCfg := srv.Config.Config.Networks["pocket"]
+	netCfg.Gateways = nil
+	srv.Config.Config.Networks["pocket"] = netCfg
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/discovery?network=pocket", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status without gateways = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestHandleAdoptDiscovered(t *testing.T) {
+	srv, _ := newDiscoveryServer(t)
+	router := setupRouter(srv)
+
+	body := `{"addresses":["pokt1cccccccccccccccccccccccccccccccccccccc","pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa","pokt1eeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee","bad"]}`
+	req := httptest.NewRequest("POST", "/api/discovery/adopt?network=pocket", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	var resp models.AdoptResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Adopted) != 1 || resp.Adopted[0] != "pokt1cccccccccccccccccccccccccccccccccccccc" {
+		t.Errorf("adopted = %v, want only pokt1cccc...", resp.Adopted)
+	}
+	reasons := map[string]string{}
+	for _, s := range resp.Skipped {
+		reasons[s.Address] = s.Reason
+	}
+	if reasons["pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"] != "already tracked" ||
+		reasons["pokt1eeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee"] != "not delegated to a configured gateway" ||
+		reasons["bad"] != "invalid address format" {
+		t.Errorf("skipped = %+v", resp.Skipped)
+	}
+	if !slices.Contains(srv.Config.Config.Networks["pocket"].Applications, "pokt1cccccccccccccccccccccccccccccccccccccc") {
+		t.Error("adopted app not added to config")
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/discovery/adopt?network=pocket", bytes.NewBufferString(`{"addresses":[]}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status for empty adopt = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestHandleGetServices_Cached(t *testing.T) {
+	srv, chain := newFakeChainServer(t)
+	router := setupRouter(srv)
+	chain.AddService("anvil", "Anvil")
+
+	get := func(url string) []models.ServiceInfo {
+		t.Helper()
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+		}
+		var services []models.ServiceInfo
+		if err := json.NewDecoder(w.Body).Decode(&services); err != nil {
+			t.Fatal(err)
+		}
+		return services
+	}
+
+	if got := get("/api/services?network=pocket"); len(got) != 1 {
+		t.Fatalf("got %d services, want 1", len(got))
+	}
+
+	chain.AddService("eth", "Ethereum")
+	if got := get("/api/services?network=pocket"); len(got) != 1 {
+		t.Errorf("got %d services from cache, want 1", len(got))
+	}
+	if got := get("/api/services?network=pocket&refresh=true"); len(got) != 2 {
+		t.Errorf("got %d services after refresh, want 2", len(got))
+	}
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/pokt-network/sam/internal/models"
+	"github.com/pokt-network/sam/internal/validate"
+)
+
+// DefaultHistoryWindow is the span of a history request without ?from=.
+const DefaultHistoryWindow = 24 * time.Hour
+
+// FlushHistory writes the points recorded since the last flush. The
+// refresher calls it after every cycle; call it once more on shutdown.
+func (s *Server) FlushHistory() {
+	if err := s.History.Flush(); err != nil {
+		s.Logger.Warn("failed to save history", "error", err)
+	}
+}
+
+// historyRange parses ?from= and ?to= (RFC 3339) and ?step= (a Go duration
+// such as 1h). to defaults to now and from to DefaultHistoryWindow before
+// it; step 0 returns every point.
+func historyRange(query url.Values) (from, to time.Time, step time.Duration, err error) {
+	to = time.Now()
+	if raw := query.Get("to"); raw != "" {
+		if to, err = time.Parse(time.RFC3339, raw); err != nil {
+			return from, to, 0, fmt.Errorf("invalid to: must be an RFC 3339 time")
+		}
+	}
+	from = to.Add(-DefaultHistoryWindow)
+	if raw := query.Get("from"); raw != "" {
+		if from, err = time.Parse(time.RFC3339, raw); err != nil {
+			return from, to, 0, fmt.Errorf("invalid from: must be an RFC 3339 time")
+		}
+	}
+	if from.After(to) {
+		return from, to, 0, fmt.Errorf("from must not be after to")
+	}
+	if raw := query.Get("step"); raw != "" {
+		if step, err = time.ParseDuration(raw); err != nil || step < 0 {
+			return from, to, 0, fmt.Errorf("invalid step: must be a duration such as 1h")
+		}
+	}
+	return from, to, step, nil
+}
+
+func (s *Server) handleGetApplicationHistory(w http.ResponseWriter, r *http.Request) {
+	address := mux.Vars(r)["address"]
+	if err := validate.Address(address); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid address format")
+		return
+	}
+
+	network := r.URL.Query().Get("network")
+	if network == "" {
+		network = "pocket"
+	}
+	if _, ok := s.Config.Config.Networks[network]; !ok {
+		respondWithError(w, http.StatusBadRequest, "invalid network")
+		return
+	}
+
+	from, to, step, err := historyRange(r.URL.Query())
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, models.AppHistoryResponse{
+		Network: network,
+		Address: address,
+		From:    from,
+		To:      to,
+		Points:  s.History.Applications(network, address, from, to, step),
+	})
+}
+
+func (s *Server) handleGetBankHistory(w http.ResponseWriter, r *http.Request) {
+	network := r.URL.Query().Get("network")
+	if network == "" {
+		network = "pocket"
+	}
+	networkConfig, ok := s.Config.Config.Networks[network]
+	if !ok {
+		respondWithError(w, http.StatusBadRequest, "invalid network")
+		return
+	}
+	if networkConfig.Bank == "" {
+		respondWithError(w, http.StatusBadRequest, "no bank account configured for network")
+		return
+	}
+
+	from, to, step, err := historyRange(r.URL.Query())
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, models.BankHistoryResponse{
+		Network: network,
+		Address: networkConfig.Bank,
+		From:    from,
+		To:      to,
+		Points:  s.History.Bank(network, networkConfig.Bank, from, to, step),
+	})
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/pokt-network/sam/internal/config"
+	"github.com/pokt-network/sam/internal/models"
+	"github.com/pokt-network/sam/internal/pocket"
+)
+
+// ParamsCacheTTL is how long a network's chain params are cached. They only
+// change through governance.
+const ParamsCacheTTL = 10 * time.Minute
+
+// chainParams returns a network's chain params from ParamsCache, fetching
+// them when missing or when refresh is set. Concurrent fetches of the same
+// network are shared.
+func (s *Server) chainParams(network string, networkConfig config.NetworkConfig, refresh bool) (models.ChainParams, error) {
+	if !refresh {
+		if params, ok := s.ParamsCache.Get(network); ok {
+			return params, nil
+		}
+	}
+
+	params, err, _ := s.paramsFlight.Do(network, func() (models.ChainParams, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), refreshTimeout)
+		defer cancel()
+
+		params, err := pocket.QueryChainParams(ctx, s.Client, network, networkConfig)
+		if err != nil {
+			return models.ChainParams{}, fmt.Errorf("failed to query chain params: %w", err)
+		}
+		s.ParamsCache.Set(network, *params)
+		return *params, nil
+	})
+	return params, err
+}
+
+// minStake returns the network's minimum application stake, or 0 when the
+// params can't be fetched: the chain still enforces it, so a failed query
+// doesn't block the request.
+func (s *Server) minStake(network string, networkConfig config.NetworkConfig) int64 {
+	params, err := s.chainParams(network, networkConfig, false)
+	if err != nil {
+		s.Logger.Warn("skipping min stake check", "network", network, "error", err)
+		return 0
+	}
+	return params.Application.MinStake
+}
+
+// belowMinStake is the error message for an amount under the minimum stake.
+func belowMinStake(what string, minStake int64) string {
+	return fmt.Sprintf("%s is below the minimum application stake of %s POKT", what, models.NewAmount(minStake).POKT())
+}
+
+// currentStake returns an app's stake from the applications cache, or from
+// the chain when the app isn't cached.
+func (s *Server) currentStake(ctx context.Context, network string, networkConfig config.NetworkConfig, address string) (int64, error) {
+	if resp, _, ok := s.AppCache.GetStale(network); ok {
+		for _, app := range resp.Applications {
+			if app.Address == address && !app.Stale {
+				return app.Stake, nil
+			}
+		}
+	}
+	app, err := s.Client.QueryApplication(ctx, address, networkConfig.APIEndpoint, network, networkConfig.BaseDenom())
+	if err != nil {
+		return 0, err
+	}
+	return app.Stake, nil
+}
+
+func (s *Server) handleGetNetworkParams(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+	networkConfig, ok := s.Config.Config.Networks[name]
+	if !ok {
+		respondWithError(w, http.StatusNotFound, "network not found")
+		return
+	}
+
+	params, err := s.chainParams(name, networkConfig, r.URL.Query().Get("refresh") == "true")
+	if err != nil {
+		s.Logger.Error("error querying chain params", "network", name, "error", err)
+		respondWithError(w, http.StatusInternalServerError, "failed to query chain params")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, params)
+}
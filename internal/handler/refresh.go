@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pokt-network/sam/internal/config"
+	"github.com/pokt-network/sam/internal/models"
+)
+
+// DefaultRefreshInterval keeps the application and bank caches warm inside
+// their 1-minute TTL.
+const DefaultRefreshInterval = 45 * time.Second
+
+// ServiceCacheTTL is how long a network's service catalog is cached.
+const ServiceCacheTTL = 10 * time.Minute
+
+// refreshTimeout bounds a shared fetch. It isn't tied to any one request,
+// since other callers may be waiting on it.
+const refreshTimeout = 30 * time.Second
+
+// flightKey keys a shared fetch by the network and its cache generation, so
+// a caller after an invalidation starts a new fetch instead of joining one
+// that began before it.
+func flightKey(network string, gen uint64) string {
+	return fmt.Sprintf("%s#%d", network, gen)
+}
+
+// refreshApplications fetches a network's applications and stores them in
+// AppCache. Concurrent calls for the same network share one fetch. If
+// AppCache is invalidated while the fetch runs, such as after a
+// transaction, the result is returned but not cached.
+func (s *Server) refreshApplications(network string, networkConfig config.NetworkConfig) models.ApplicationsResponse {
+	gen := s.AppCache.Generation(network)
+	resp, _, _ := s.appFlight.Do(flightKey(network, gen), func() (models.ApplicationsResponse, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), refreshTimeout)
+		defer cancel()
+
+		resp := s.fetchApplications(ctx, network, networkConfig)
+		s.AppCache.SetIfCurrent(network, resp, gen)
+		s.History.RecordApplications(network, resp.Applications, resp.FetchedAt)
+		return resp, nil
+	})
+	return resp
+}
+
+// refreshBank fetches a network's bank account and stores it in BankCache,
+// unless BankCache was invalidated meanwhile. Concurrent calls for the same
+// network share one fetch.
+func (s *Server) refreshBank(network string, networkConfig config.NetworkConfig) (models.BankAccount, error) {
+	gen := s.BankCache.Generation(network)
+	bank, err, _ := s.bankFlight.Do(flightKey(network, gen), func() (models.BankAccount, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), refreshTimeout)
+		defer cancel()
+
+		bank, err := s.Client.QueryBankAccount(ctx, networkConfig.Bank, networkConfig.APIEndpoint, network, networkConfig.BaseDenom())
+		if err != nil {
+			return models.BankAccount{}, fmt.Errorf("failed to query bank account: %w", err)
+		}
+		s.BankCache.SetIfCurrent(network, *bank, gen)
+		s.History.RecordBank(network, *bank, time.Now())
+		return *bank, nil
+	})
+	return bank, err
+}
+
+// RefreshAll refreshes the applications and bank account of every
+// configured network in parallel, then flushes the history they recorded.
+func (s *Server) RefreshAll() {
+	var wg sync.WaitGroup
+	for network, networkConfig := range s.Config.Config.Networks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.refreshApplications(network, networkConfig)
+		}()
+
+		if networkConfig.Bank == "" {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := s.refreshBank(network, networkConfig); err != nil {
+				s.Logger.Warn("background bank refresh failed", "network", network, "error", err)
+			}
+		}()
+	}
+	wg.Wait()
+	s.FlushHistory()
+}
+
+// RunRefresher keeps the caches warm: it refreshes every network right away
+// and then every interval until ctx is cancelled.
+func (s *Server) RunRefresher(ctx context.Context, interval time.Duration) {
+	s.Logger.Info("cache refresher started", "interval", interval)
+	s.RefreshAll()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.Logger.Info("cache refresher stopped")
+			return
+		case <-ticker.C:
+			s.RefreshAll()
+		}
+	}
+}
@@ -18,11 +18,33 @@ func (s *Server) SetupRoutes(r *mux.Router) {
 	api.HandleFunc("/applications/{address}/fund", s.handleFund).Methods("POST")
 	api.HandleFunc("/applications/{address}/autotopup", s.handleSetAutoTopUp).Methods("PUT")
 	api.HandleFunc("/applications/{address}/autotopup", s.handleDeleteAutoTopUp).Methods("DELETE")
+	api.HandleFunc("/applications/{address}/authz", s.handleGetStakeGrant).Methods("GET")
+	api.HandleFunc("/applications/{address}/authz", s.handleGrantStake).Methods("POST")
+	api.HandleFunc("/applications/{address}/authz", s.handleRevokeStake).Methods("DELETE")
+	api.HandleFunc("/applications/{address}/feegrant", s.handleGetFeeGrant).Methods("GET")
+	api.HandleFunc("/applications/{address}/feegrant", s.handleSetFeeGrant).Methods("PUT")
+	api.HandleFunc("/applications/{address}/feegrant", s.handleRevokeFeeGrant).Methods("DELETE")
+	api.HandleFunc("/applications/{address}/sweep", s.handleSweep).Methods("POST")
+	api.HandleFunc("/applications/{address}/sweep/policy", s.handleSetSweepPolicy).Methods("PUT")
+	api.HandleFunc("/applications/{address}/sweep/policy", s.handleDeleteSweepPolicy).Methods("DELETE")
+	api.HandleFunc("/applications/{address}/history", s.handleGetApplicationHistory).Methods("GET")
+	api.HandleFunc("/discovery", s.handleGetDiscovery).Methods("GET")
+	api.HandleFunc("/discovery/adopt", s.handleAdoptDiscovered).Methods("POST")
 	api.HandleFunc("/bank", s.handleGetBank).Methods("GET")
+	api.HandleFunc("/bank/history", s.handleGetBankHistory).Methods("GET")
 	api.HandleFunc("/networks", s.handleGetNetworks).Methods("GET")
+	api.HandleFunc("/networks/{name}/endpoints", s.handleGetNetworkEndpoints).Methods("GET")
+	api.HandleFunc("/networks/{name}/params", s.handleGetNetworkParams).Methods("GET")
 	api.HandleFunc("/services", s.handleGetServices).Methods("GET")
 	api.HandleFunc("/autotopup", s.handleGetAutoTopUp).Methods("GET")
 	api.HandleFunc("/autotopup/events", s.handleGetAutoTopUpEvents).Methods("GET")
+	api.HandleFunc("/sweep", s.handleGetSweep).Methods("GET")
+	api.HandleFunc("/pending", s.handleGetPending).Methods("GET")
+	api.HandleFunc("/pending/{id}", s.handleGetPendingTx).Methods("GET")
+	api.HandleFunc("/pending/{id}", s.handleDeletePendingTx).Methods("DELETE")
+	api.HandleFunc("/pending/{id}/unsigned", s.handleDownloadUnsignedTx).Methods("GET")
+	api.HandleFunc("/pending/{id}/signed", s.handleSubmitSignedTx).Methods("POST")
+	api.HandleFunc("/pending/{id}/signatures/{signer}", s.handleSubmitSignature).Methods("POST")
 	api.HandleFunc("/config", s.handleGetConfig).Methods("GET")
 
 	r.HandleFunc("/", s.handleFrontend).Methods("GET")
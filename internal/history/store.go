@@ -0,0 +1,337 @@
+// Package history records application stakes and bank balances over time
+// and serves them as time series.
+package history
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pokt-network/sam/internal/config"
+	"github.com/pokt-network/sam/internal/jsonfile"
+	"github.com/pokt-network/sam/internal/models"
+)
+
+// Policy controls how densely points are recorded and how long they are
+// kept. Points older than DownsampleAfter are thinned to one per
+// DownsampleResolution; points older than Retention are dropped.
+type Policy struct {
+	Resolution           time.Duration
+	Retention            time.Duration
+	DownsampleAfter      time.Duration
+	DownsampleResolution time.Duration
+}
+
+// DefaultPolicy records a point every 5 minutes, keeps hourly points after
+// a day and drops them after 30 days.
+var DefaultPolicy = Policy{
+	Resolution:           5 * time.Minute,
+	Retention:            30 * 24 * time.Hour,
+	DownsampleAfter:      24 * time.Hour,
+	DownsampleResolution: time.Hour,
+}
+
+// PolicyFromConfig returns DefaultPolicy overridden by the history config.
+func PolicyFromConfig(cfg *config.Config) Policy {
+	p := DefaultPolicy
+	h := cfg.Config.History
+	if h.Resolution > 0 {
+		p.Resolution = h.Resolution
+	}
+	if h.Retention > 0 {
+		p.Retention = h.Retention
+	}
+	if h.DownsampleAfter > 0 {
+		p.DownsampleAfter = h.DownsampleAfter
+	}
+	if h.DownsampleResolution > 0 {
+		p.DownsampleResolution = h.DownsampleResolution
+	}
+	return p
+}
+
+// StoreData maps network -> address -> points, oldest first.
+type StoreData struct {
+	Applications map[string]map[string][]models.AppHistoryPoint  `json:"applications"`
+	Banks        map[string]map[string][]models.BankHistoryPoint `json:"banks"`
+}
+
+// Store provides thread-safe persistence for stake and balance history.
+// Points are recorded in memory and written by Flush, so a refresh cycle
+// costs one write however many series it touched. A nil Store records
+// nothing and returns empty series.
+type Store struct {
+	mu     sync.RWMutex
+	path   string
+	policy Policy
+	data   StoreData
+	dirty  bool // points changed since the last Flush
+	// compactAt is when the oldest point not yet compacted ages past the
+	// downsample boundary or the retention; zero when there are no points.
+	compactAt time.Time
+}
+
+// NewStore loads or creates a history file.
+func NewStore(path string, policy Policy) (*Store, error) {
+	s := &Store{
+		path:   path,
+		policy: policy,
+		data: StoreData{
+			Applications: make(map[string]map[string][]models.AppHistoryPoint),
+			Banks:        make(map[string]map[string][]models.BankHistoryPoint),
+		},
+	}
+
+	if err := jsonfile.Load(path, &s.data); err != nil {
+		return nil, err
+	}
+	if s.data.Applications == nil {
+		s.data.Applications = make(map[string]map[string][]models.AppHistoryPoint)
+	}
+	if s.data.Banks == nil {
+		s.data.Banks = make(map[string]map[string][]models.BankHistoryPoint)
+	}
+	// Loaded points may not have been compacted yet.
+	s.schedule(time.Time{})
+
+	return s, nil
+}
+
+// RecordApplications adds a point at time at for each app whose last point
+// is at least the policy resolution older. Stale apps are skipped, since
+// their values were not just queried.
+func (s *Store) RecordApplications(network string, apps []models.Application, at time.Time) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.data.Applications[network] == nil {
+		s.data.Applications[network] = make(map[string][]models.AppHistoryPoint)
+	}
+	series := s.data.Applications[network]
+
+	added := false
+	for _, app := range apps {
+		if app.Stale {
+			continue
+		}
+		point := models.AppHistoryPoint{Time: at, Stake: app.Stake, LiquidBalance: app.LiquidBalance}
+		if points, ok := appendPoint(series[app.Address], point, appTime, s.policy.Resolution); ok {
+			series[app.Address] = points
+			added = true
+		}
+	}
+	if added {
+		s.added(at)
+	}
+}
+
+// RecordBank adds a point at time at for the bank account unless its last
+// point is less than the policy resolution older.
+func (s *Store) RecordBank(network string, bank models.BankAccount, at time.Time) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.data.Banks[network] == nil {
+		s.data.Banks[network] = make(map[string][]models.BankHistoryPoint)
+	}
+	series := s.data.Banks[network]
+
+	point := models.BankHistoryPoint{Time: at, Balance: bank.Balance}
+	points, ok := appendPoint(series[bank.Address], point, bankTime, s.policy.Resolution)
+	if !ok {
+		return
+	}
+	series[bank.Address] = points
+	s.added(at)
+}
+
+// Flush writes the store if points were recorded or compacted since the
+// last Flush.
+func (s *Store) Flush() error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.dirty {
+		return nil
+	}
+	if err := jsonfile.WriteAtomic(s.path, s.data); err != nil {
+		return err
+	}
+	s.dirty = false
+	return nil
+}
+
+// Applications returns an app's points between from and to, inclusive. A
+// positive step keeps only the last point in each step.
+func (s *Store) Applications(network, address string, from, to time.Time, step time.Duration) []models.AppHistoryPoint {
+	if s == nil {
+		return []models.AppHistoryPoint{}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return window(s.data.Applications[network][address], from, to, step, appTime)
+}
+
+// Bank returns a bank account's points between from and to, inclusive. A
+// positive step keeps only the last point in each step.
+func (s *Store) Bank(network, address string, from, to time.Time, step time.Duration) []models.BankHistoryPoint {
+	if s == nil {
+		return []models.BankHistoryPoint{}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return window(s.data.Banks[network][address], from, to, step, bankTime)
+}
+
+// added marks the store dirty after a point at time at was appended and
+// compacts it if any point has aged out by then. Callers must hold mu.
+func (s *Store) added(at time.Time) {
+	s.dirty = true
+	if due := at.Add(min(s.policy.DownsampleAfter, s.policy.Retention)); s.compactAt.IsZero() || due.Before(s.compactAt) {
+		s.compactAt = due
+	}
+	if !at.Before(s.compactAt) {
+		s.compact(at)
+	}
+}
+
+// compact applies retention and downsampling to every series as of now,
+// drops the empty ones and schedules the next compaction. Callers must
+// hold mu.
+func (s *Store) compact(now time.Time) {
+	for network, series := range s.data.Applications {
+		for address, points := range series {
+			kept := compact(points, now, s.policy, appTime)
+			if len(kept) != len(points) {
+				s.dirty = true
+			}
+			if series[address] = kept; len(kept) == 0 {
+				delete(series, address)
+			}
+		}
+		if len(series) == 0 {
+			delete(s.data.Applications, network)
+		}
+	}
+	for network, series := range s.data.Banks {
+		for address, points := range series {
+			kept := compact(points, now, s.policy, bankTime)
+			if len(kept) != len(points) {
+				s.dirty = true
+			}
+			if series[address] = kept; len(kept) == 0 {
+				delete(series, address)
+			}
+		}
+		if len(series) == 0 {
+			delete(s.data.Banks, network)
+		}
+	}
+	s.schedule(now.Add(-s.policy.DownsampleAfter))
+}
+
+// schedule sets compactAt to when the next point ages out, given that the
+// points before thinBefore are already downsampled. Callers must hold mu.
+func (s *Store) schedule(thinBefore time.Time) {
+	s.compactAt = time.Time{}
+	next := func(t time.Time) {
+		if s.compactAt.IsZero() || t.Before(s.compactAt) {
+			s.compactAt = t
+		}
+	}
+	for _, series := range s.data.Applications {
+		for _, points := range series {
+			next(ageOut(points, thinBefore, s.policy, appTime))
+		}
+	}
+	for _, series := range s.data.Banks {
+		for _, points := range series {
+			next(ageOut(points, thinBefore, s.policy, bankTime))
+		}
+	}
+}
+
+func appTime(p models.AppHistoryPoint) time.Time   { return p.Time }
+func bankTime(p models.BankHistoryPoint) time.Time { return p.Time }
+
+// appendPoint appends p unless the last point is less than resolution
+// older, and reports whether it did.
+func appendPoint[P any](points []P, p P, at func(P) time.Time, resolution time.Duration) ([]P, bool) {
+	if n := len(points); n > 0 && at(p).Sub(at(points[n-1])) < resolution {
+		return points, false
+	}
+	return append(points, p), true
+}
+
+// compact drops points older than the retention and thins those older than
+// DownsampleAfter to the last point in each DownsampleResolution.
+func compact[P any](points []P, now time.Time, policy Policy, at func(P) time.Time) []P {
+	keepFrom := now.Add(-policy.Retention)
+	thinBefore := now.Add(-policy.DownsampleAfter)
+
+	start := 0
+	for start < len(points) && at(points[start]).Before(keepFrom) {
+		start++
+	}
+	end := start
+	for end < len(points) && at(points[end]).Before(thinBefore) {
+		end++
+	}
+
+	kept := lastPerStep(points[start:end], policy.DownsampleResolution, at)
+	return append(kept, points[end:]...)
+}
+
+// ageOut returns when the next of the compacted points crosses the
+// retention or, past thinBefore, the downsample boundary.
+func ageOut[P any](points []P, thinBefore time.Time, policy Policy, at func(P) time.Time) time.Time {
+	next := at(points[0]).Add(policy.Retention)
+	for _, p := range points {
+		if t := at(p); !t.Before(thinBefore) {
+			if due := t.Add(policy.DownsampleAfter); due.Before(next) {
+				next = due
+			}
+			break
+		}
+	}
+	return next
+}
+
+// window copies the points between from and to, inclusive, keeping the
+// last point in each step when step is positive. It never returns nil.
+func window[P any](points []P, from, to time.Time, step time.Duration, at func(P) time.Time) []P {
+	var inRange []P
+	for _, p := range points {
+		if t := at(p); !t.Before(from) && !t.After(to) {
+			inRange = append(inRange, p)
+		}
+	}
+	if inRange = lastPerStep(inRange, step, at); inRange == nil {
+		return []P{}
+	}
+	return inRange
+}
+
+// lastPerStep returns a new slice with the last of the points in each
+// step-long interval, aligned to the zero time; all of them if step is not
+// positive.
+func lastPerStep[P any](points []P, step time.Duration, at func(P) time.Time) []P {
+	var out []P
+	for i, p := range points {
+		if step > 0 && i+1 < len(points) && at(points[i+1]).Truncate(step).Equal(at(p).Truncate(step)) {
+			continue
+		}
+		out = append(out, p)
+	}
+	return out
+}
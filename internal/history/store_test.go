@@ -0,0 +1,184 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pokt-network/sam/internal/config"
+	"github.com/pokt-network/sam/internal/models"
+)
+
+const (
+	testApp  = "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	testBank = "pokt1bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+)
+
+var testStart = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newTestStore(t *testing.T, policy Policy) (*Store, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "history.json")
+	s, err := NewStore(path, policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, path
+}
+
+func TestStore_RecordRespectsResolution(t *testing.T) {
+	s, path := newTestStore(t, DefaultPolicy)
+
+	record := func(offset time.Duration, stake int64) {
+		t.Helper()
+		apps := []models.Application{{Address: testApp, Stake: stake, LiquidBalance: 7}}
+		s.RecordApplications("pocket", apps, testStart.Add(offset))
+	}
+	record(0, 100)
+	record(time.Minute, 200) // inside the 5m resolution: skipped
+	record(5*time.Minute, 300)
+
+	// Stale values were not just queried and are never recorded.
+	stale := []models.Application{{Address: testApp, Stake: 999, Stale: true}}
+	s.RecordApplications("pocket", stale, testStart.Add(time.Hour))
+
+	got := s.Applications("pocket", testApp, testStart, testStart.Add(time.Hour), 0)
+	if len(got) != 2 || got[0].Stake != 100 || got[1].Stake != 300 || got[1].LiquidBalance != 7 {
+		t.Errorf("Applications() = %+v, want stakes 100 and 300", got)
+	}
+
+	// The points survive a flush and reload.
+	if err := s.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	reloaded, err := NewStore(path, DefaultPolicy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.Applications("pocket", testApp, testStart, testStart.Add(time.Hour), 0); len(got) != 2 {
+		t.Errorf("reloaded Applications() = %+v, want 2 points", got)
+	}
+}
+
+func TestStore_DownsamplesAndExpires(t *testing.T) {
+	policy := Policy{
+		Resolution:           10 * time.Minute,
+		Retention:            48 * time.Hour,
+		DownsampleAfter:      12 * time.Hour,
+		DownsampleResolution: time.Hour,
+	}
+	s, _ := newTestStore(t, policy)
+
+	// Three days of points every 10 minutes.
+	end := 72 * time.Hour
+	for offset := time.Duration(0); offset <= end; offset += 10 * time.Minute {
+		bank := models.BankAccount{Address: testBank, Balance: int64(offset / time.Minute)}
+		s.RecordBank("pocket", bank, testStart.Add(offset))
+	}
+
+	now := testStart.Add(end)
+	got := s.Bank("pocket", testBank, testStart, now, 0)
+	if first := got[0].Time; first.Before(now.Add(-policy.Retention)) {
+		t.Errorf("oldest point %v is past the retention", first)
+	}
+
+	// 36 hourly points before the downsample boundary, 10-minute points after.
+	var hourly, recent int
+	for _, p := range got {
+		if p.Time.Before(now.Add(-policy.DownsampleAfter)) {
+			hourly++
+			if p.Time.Minute() != 50 {
+				t.Errorf("downsampled point at %v, want the last of its hour", p.Time)
+			}
+		} else {
+			recent++
+		}
+	}
+	if hourly != 36 || recent != 73 {
+		t.Errorf("hourly, recent points = %d, %d; want 36, 73", hourly, recent)
+	}
+}
+
+func TestStore_FlushWritesOnlyChanges(t *testing.T) {
+	s, path := newTestStore(t, DefaultPolicy)
+	if err := os.Remove(path); err != nil { // created empty by NewStore
+		t.Fatal(err)
+	}
+
+	s.RecordBank("pocket", models.BankAccount{Address: testBank, Balance: 1}, testStart)
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("history file written before Flush: %v", err)
+	}
+	if err := s.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("history file after Flush: %v", err)
+	}
+
+	// Nothing new to write: a point inside the resolution is skipped.
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	s.RecordBank("pocket", models.BankAccount{Address: testBank, Balance: 2}, testStart.Add(time.Minute))
+	if err := s.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Flush() rewrote an unchanged store: %v", err)
+	}
+}
+
+func TestStore_Window(t *testing.T) {
+	s, _ := newTestStore(t, Policy{Resolution: time.Minute, Retention: 24 * time.Hour, DownsampleAfter: 24 * time.Hour, DownsampleResolution: time.Hour})
+	for i := range 12 {
+		apps := []models.Application{{Address: testApp, Stake: int64(i)}}
+		s.RecordApplications("pocket", apps, testStart.Add(time.Duration(i)*10*time.Minute))
+	}
+
+	from, to := testStart.Add(20*time.Minute), testStart.Add(90*time.Minute)
+	if got := s.Applications("pocket", testApp, from, to, 0); len(got) != 8 || got[0].Stake != 2 || got[7].Stake != 9 {
+		t.Errorf("Applications(20m..90m) = %+v, want stakes 2 to 9", got)
+	}
+	got := s.Applications("pocket", testApp, testStart, testStart.Add(2*time.Hour), 30*time.Minute)
+	want := []int64{2, 5, 8, 11}
+	if len(got) != len(want) {
+		t.Fatalf("Applications(step 30m) = %+v, want stakes %v", got, want)
+	}
+	for i := range want {
+		if got[i].Stake != want[i] {
+			t.Errorf("point %d stake = %d, want %d", i, got[i].Stake, want[i])
+		}
+	}
+
+	if got := s.Applications("pocket", "pokt1cccccccccccccccccccccccccccccccccccccc", testStart, to, 0); got == nil || len(got) != 0 {
+		t.Errorf("unknown app = %#v, want an empty slice", got)
+	}
+}
+
+func TestStore_Nil(t *testing.T) {
+	var s *Store
+	s.RecordBank("pocket", models.BankAccount{Address: testBank, Balance: 1}, testStart)
+	if err := s.Flush(); err != nil {
+		t.Errorf("nil Flush() error = %v", err)
+	}
+	if got := s.Bank("pocket", testBank, testStart, testStart.Add(time.Hour), 0); got == nil || len(got) != 0 {
+		t.Errorf("nil Bank() = %#v, want an empty slice", got)
+	}
+	if got := s.Applications("pocket", testApp, testStart, testStart.Add(time.Hour), 0); got == nil || len(got) != 0 {
+		t.Errorf("nil Applications() = %#v, want an empty slice", got)
+	}
+}
+
+func TestPolicyFromConfig(t *testing.T) {
+	cfg := &config.Config{}
+	if got := PolicyFromConfig(cfg); got != DefaultPolicy {
+		t.Errorf("PolicyFromConfig(empty) = %+v, want defaults", got)
+	}
+	cfg.Config.History.Retention = 7 * 24 * time.Hour
+	got := PolicyFromConfig(cfg)
+	if got.Retention != 7*24*time.Hour || got.Resolution != DefaultPolicy.Resolution {
+		t.Errorf("PolicyFromConfig() = %+v, want retention overridden only", got)
+	}
+}
@@ -0,0 +1,69 @@
+// Package jsonfile persists small JSON documents to disk atomically.
+package jsonfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Load reads path into v, creating the file from v when it does not exist.
+func Load(path string, v any) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := WriteAtomic(path, v); err != nil {
+			return fmt.Errorf("failed to create %s: %w", filepath.Base(path), err)
+		}
+		return nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+	}
+
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, v); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+		}
+	}
+
+	return nil
+}
+
+// WriteAtomic marshals v and writes it to path (temp file + fsync + rename).
+func WriteAtomic(path string, v any) error {
+	raw, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", filepath.Base(path), err)
+	}
+
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))+"-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to sync temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to rename temp file: %w", err)
+	}
+
+	return nil
+}
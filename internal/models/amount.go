@@ -0,0 +1,252 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Denom is the base denomination of POKT, and of an Amount that doesn't
+// name one.
+const Denom = "upokt"
+
+// UpoktPerPOKT is the number of uPOKT in one POKT.
+const UpoktPerPOKT = 1_000_000
+
+// poktDecimals is the number of decimal places of a POKT amount.
+const poktDecimals = 6
+
+// maxAmountLength bounds the amount strings accepted, well above any real
+// supply.
+const maxAmountLength = 64
+
+// Amount is a coin amount in a base denom (uPOKT unless it names another)
+// backed by a big integer, so it neither overflows nor loses precision. The
+// zero value is 0.
+//
+// In JSON it is read from a whole-token number (100.5), a whole-token
+// decimal string ("1234.567891" or "1234.567891pokt") or a base-denom
+// string ("1234567891upokt", "5000ustake"), and written as a base-denom
+// string. Whole tokens have 6 decimal places. A bare number names no
+// denom; In gives it the network's.
+//
+// Amount covers the API boundary only: models, stores, the client and the
+// worker keep int64 base units. Every narrowing into them is checked, so a
+// value beyond int64 is an error rather than a wrapped number: request
+// amounts by validate.POKTAmount and POKTFloor, chain coins by Coin.Upokt,
+// and stake and top-up sums by validate.StakeAddition and AmountSum.
+type Amount struct {
+	value *big.Int
+	denom string // "" until known; String writes Denom
+}
+
+// NewAmount returns an Amount of upokt uPOKT.
+func NewAmount(upokt int64) Amount {
+	return Amount{value: big.NewInt(upokt)}
+}
+
+// NewAmountOf returns an Amount of value in the base denom denom.
+func NewAmountOf(value int64, denom string) Amount {
+	return Amount{value: big.NewInt(value), denom: denom}
+}
+
+// ParseAmount parses an amount in the base denom denom: "5000ustake" (with
+// denom "ustake") as base units, and a decimal such as "1234.567891" as
+// whole tokens. "1234.567891pokt" is also accepted for uPOKT. An amount
+// naming another denom is an error.
+func ParseAmount(s, denom string) (Amount, error) {
+	a, err := parseAmount(s)
+	if err != nil {
+		return Amount{}, err
+	}
+	return a.In(denom)
+}
+
+// parseAmount parses an amount in whatever denom it names, if any.
+func parseAmount(s string) (Amount, error) {
+	s = strings.TrimSpace(s)
+	if len(s) > maxAmountLength {
+		return Amount{}, errors.New("amount is too long")
+	}
+	i := strings.IndexFunc(s, func(c rune) bool {
+		return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+	})
+	if i < 0 {
+		return parsePOKT(s)
+	}
+	digits, denom := s[:i], s[i:]
+	if denom == "pokt" {
+		a, err := parsePOKT(digits)
+		a.denom = Denom
+		return a, err
+	}
+	a, err := parseUpokt(digits)
+	a.denom = denom
+	return a, err
+}
+
+// ParseUpokt parses an integer amount in base units, as the chain returns in
+// coins. The result names no denom.
+func ParseUpokt(s string) (Amount, error) {
+	if len(s) > maxAmountLength {
+		return Amount{}, errors.New("amount is too long")
+	}
+	return parseUpokt(s)
+}
+
+func parseUpokt(s string) (Amount, error) {
+	if !isDecimalInteger(s) {
+		return Amount{}, fmt.Errorf("invalid base-denom amount %q: must be a whole number", s)
+	}
+	v, _ := new(big.Int).SetString(s, 10)
+	return Amount{value: v}, nil
+}
+
+func parsePOKT(s string) (Amount, error) {
+	whole, frac, hasPoint := strings.Cut(s, ".")
+	if !isDecimalInteger(whole) || hasPoint && (frac == "" || !isDigits(frac)) {
+		return Amount{}, fmt.Errorf("invalid amount %q: must be a decimal number", s)
+	}
+	if len(frac) > poktDecimals {
+		return Amount{}, fmt.Errorf("invalid amount %q: more than %d decimal places", s, poktDecimals)
+	}
+	v, _ := new(big.Int).SetString(whole+frac+strings.Repeat("0", poktDecimals-len(frac)), 10)
+	return Amount{value: v}, nil
+}
+
+// isDecimalInteger reports whether s is an optionally signed run of digits.
+func isDecimalInteger(s string) bool {
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+	return isDigits(s)
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (a Amount) int() *big.Int {
+	if a.value == nil {
+		return new(big.Int)
+	}
+	return a.value
+}
+
+// Denom returns the amount's base denom, Denom if it names none.
+func (a Amount) Denom() string {
+	if a.denom == "" {
+		return Denom
+	}
+	return a.denom
+}
+
+// In returns the amount in denom. An amount naming no denom takes denom;
+// one naming another denom is an error.
+func (a Amount) In(denom string) (Amount, error) {
+	if a.denom != "" && a.denom != denom {
+		return Amount{}, fmt.Errorf("amount is in %s, not the network denom %s", a.denom, denom)
+	}
+	a.denom = denom
+	return a, nil
+}
+
+// Sign returns -1, 0 or +1 for a negative, zero or positive amount.
+func (a Amount) Sign() int {
+	return a.int().Sign()
+}
+
+// Int64 returns the amount in base units and whether it fits in an int64.
+func (a Amount) Int64() (int64, bool) {
+	v := a.int()
+	return v.Int64(), v.IsInt64()
+}
+
+// BigInt returns a copy of the amount in base units.
+func (a Amount) BigInt() *big.Int {
+	return new(big.Int).Set(a.int())
+}
+
+// String returns the amount in base units with its denom, such as
+// "1234567891upokt".
+func (a Amount) String() string {
+	return a.int().String() + a.Denom()
+}
+
+// POKT returns the amount as a whole-token decimal without trailing zeros,
+// such as "1234.567891" or "12".
+func (a Amount) POKT() string {
+	v := a.int()
+	sign := ""
+	if v.Sign() < 0 {
+		sign = "-"
+	}
+	digits := new(big.Int).Abs(v).String()
+	if len(digits) <= poktDecimals {
+		digits = strings.Repeat("0", poktDecimals-len(digits)+1) + digits
+	}
+	whole, frac := digits[:len(digits)-poktDecimals], strings.TrimRight(digits[len(digits)-poktDecimals:], "0")
+	if frac == "" {
+		return sign + whole
+	}
+	return sign + whole + "." + frac
+}
+
+// MarshalJSON writes the amount as a base-denom string.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON reads a whole-token number, or a string accepted by
+// ParseAmount in any denom. A number is parsed from its literal digits, not
+// through a float64. Callers check the denom with In.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	s := string(data)
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		parsed, err := parseAmount(s)
+		if err != nil {
+			return err
+		}
+		*a = parsed
+		return nil
+	}
+	if len(s) > maxAmountLength {
+		return errors.New("amount is too long")
+	}
+	parsed, err := parsePOKT(s)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// Upokt parses the coin's amount as uPOKT. Amounts beyond int64 are
+// reported as errors instead of wrapping.
+func (c Coin) Upokt() (int64, error) {
+	amount, err := ParseUpokt(c.Amount)
+	if err != nil {
+		return 0, err
+	}
+	v, ok := amount.Int64()
+	if !ok {
+		return 0, fmt.Errorf("amount %s%s is out of range", c.Amount, c.Denom)
+	}
+	return v, nil
+}
@@ -0,0 +1,151 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"1", "1000000upokt", false},
+		{"1234.567891", "1234567891upokt", false},
+		{"0.000001", "1upokt", false},
+		{"1.5pokt", "1500000upokt", false},
+		{"1234567891upokt", "1234567891upokt", false},
+		{" 42 ", "42000000upokt", false},
+		{"-2.5", "-2500000upokt", false},
+		{"100000000000000000000", "100000000000000000000000000upokt", false},
+		{"1.2345678", "", true},
+		{"1.5upokt", "", true},
+		{"1e6", "", true},
+		{"1.", "", true},
+		{".5", "", true},
+		{"", "", true},
+		{"abc", "", true},
+		{"10POKT", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseAmount(tt.in, Denom)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAmount(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if !tt.wantErr && got.String() != tt.want {
+				t.Errorf("ParseAmount(%q) = %s, want %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAmount_NetworkDenom(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"5000ustake", "5000ustake", false},
+		{"1.5", "1500000ustake", false},
+		{"5000upokt", "", true},
+		{"1.5pokt", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseAmount(tt.in, "ustake")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAmount(%q, ustake) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if !tt.wantErr && got.String() != tt.want {
+				t.Errorf("ParseAmount(%q, ustake) = %s, want %s", tt.in, got, tt.want)
+			}
+		})
+	}
+
+	// A decoded amount keeps its denom until In checks it.
+	var a Amount
+	if err := json.Unmarshal([]byte(`"42ustake"`), &a); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if _, err := a.In(Denom); err == nil {
+		t.Errorf("%s.In(%s) succeeded, want a denom mismatch", a, Denom)
+	}
+	if out, err := json.Marshal(NewAmountOf(42, "ustake")); err != nil || string(out) != `"42ustake"` {
+		t.Errorf("Marshal = %s, %v; want \"42ustake\"", out, err)
+	}
+}
+
+func TestAmount_POKT(t *testing.T) {
+	tests := []struct {
+		upokt int64
+		want  string
+	}{
+		{0, "0"},
+		{1, "0.000001"},
+		{1_500_000, "1.5"},
+		{12_000_000, "12"},
+		{1_234_567_891, "1234.567891"},
+		{-250_000, "-0.25"},
+	}
+
+	for _, tt := range tests {
+		if got := NewAmount(tt.upokt).POKT(); got != tt.want {
+			t.Errorf("NewAmount(%d).POKT() = %q, want %q", tt.upokt, got, tt.want)
+		}
+	}
+}
+
+func TestAmount_JSON(t *testing.T) {
+	var req struct {
+		Number Amount  `json:"number"`
+		String Amount  `json:"string"`
+		Upokt  Amount  `json:"upokt"`
+		Absent *Amount `json:"absent"`
+	}
+	// Numbers are read from their literal digits, without float error.
+	body := `{"number":1234.567891,"string":"0.3","upokt":"9223372036854775808upokt"}`
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if req.Number.String() != "1234567891upokt" || req.String.String() != "300000upokt" {
+		t.Errorf("number = %s, string = %s", req.Number, req.String)
+	}
+	if _, ok := req.Upokt.Int64(); ok {
+		t.Errorf("Int64 of %s reported ok, want overflow", req.Upokt)
+	}
+	if req.Absent != nil {
+		t.Errorf("absent = %v, want nil", req.Absent)
+	}
+
+	out, err := json.Marshal(req.Upokt)
+	if err != nil || string(out) != `"9223372036854775808upokt"` {
+		t.Errorf("Marshal = %s, %v", out, err)
+	}
+	var back Amount
+	if err := json.Unmarshal(out, &back); err != nil || back.String() != req.Upokt.String() {
+		t.Errorf("round trip = %s, %v", back, err)
+	}
+
+	for _, bad := range []string{`1e3`, `"1.0000001"`, `true`, `{}`} {
+		var a Amount
+		if err := json.Unmarshal([]byte(bad), &a); err == nil {
+			t.Errorf("Unmarshal(%s) = %s, want error", bad, a)
+		}
+	}
+}
+
+func TestCoin_Upokt(t *testing.T) {
+	if got, err := (Coin{Denom: Denom, Amount: "1234567891"}).Upokt(); err != nil || got != 1_234_567_891 {
+		t.Errorf("Upokt() = %d, %v", got, err)
+	}
+	if _, err := (Coin{Denom: Denom, Amount: "9223372036854775808"}).Upokt(); err == nil {
+		t.Error("Upokt() of an int64 overflow succeeded")
+	}
+	if _, err := (Coin{Denom: Denom, Amount: "1.5"}).Upokt(); err == nil {
+		t.Error("Upokt() of a decimal succeeded")
+	}
+}
@@ -2,33 +2,270 @@ package models
 
 import "time"
 
-// Application represents a staked Pocket Network application.
+// Application represents a staked Pocket Network application. ServiceID and
+// Gateway are the first of ServiceIDs and Gateways, kept for clients that
+// predate the full lists.
 type Application struct {
-	Address       string `json:"address"`
-	ServiceID     string `json:"service_id"`
-	Stake         int64  `json:"stake"`
-	LiquidBalance int64  `json:"liquid_balance"`
-	Gateway       string `json:"gateway"`
-	Network       string `json:"network"`
+	Address       string   `json:"address"`
+	ServiceID     string   `json:"service_id"`
+	ServiceIDs    []string `json:"service_ids"`
+	Stake         int64    `json:"stake"`
+	LiquidBalance int64    `json:"liquid_balance"`
+	Gateway       string   `json:"gateway"`
+	Gateways      []string `json:"gateways"`
+	Network       string   `json:"network"`
+
+	// Balances holds every coin in the app's account; LiquidBalance is
+	// the amount of Denom, the network's base denom, among them.
+	Denom    string `json:"denom,omitempty"`
+	Balances []Coin `json:"balances,omitempty"`
+
+	// PendingUndelegations are gateways being undelegated, by the session
+	// end height at which the undelegation takes effect.
+	PendingUndelegations []PendingUndelegation `json:"pending_undelegations,omitempty"`
+	PendingTransfer      *PendingTransfer      `json:"pending_transfer,omitempty"`
+	// UnstakeSessionEndHeight is the session end height at which an
+	// unstake completes; 0 when the app is not unstaking.
+	UnstakeSessionEndHeight int64 `json:"unstake_session_end_height,omitempty"`
+
+	StakeGrant   *AuthzGrant   `json:"stake_grant,omitempty"`   // bank's authz grant to stake for this app
+	FeeAllowance *FeeAllowance `json:"fee_allowance,omitempty"` // bank's fee grant to this app
+	FetchedAt    *time.Time    `json:"fetched_at,omitempty"`    // when the data was queried
+	Height       int64         `json:"height,omitempty"`        // chain height when the data was queried
+	Stale        bool          `json:"stale,omitempty"`         // last known value; the latest query failed
+}
+
+// PendingUndelegation lists the gateways an app is undelegating from that
+// take effect at SessionEndHeight.
+type PendingUndelegation struct {
+	SessionEndHeight int64    `json:"session_end_height"`
+	Gateways         []string `json:"gateways"`
+}
+
+// PendingTransfer is an in-progress transfer of the app's stake to
+// DestinationAddress, completing at SessionEndHeight.
+type PendingTransfer struct {
+	DestinationAddress string `json:"destination_address"`
+	SessionEndHeight   int64  `json:"session_end_height"`
+}
+
+// ApplicationsResponseVersion is the ApplicationsResponse version with
+// numeric amounts; AmountsVersion is the same response with string amounts.
+const (
+	ApplicationsResponseVersion = 2
+	AmountsVersion              = 3
+)
+
+// ApplicationsResponse is the versioned /api/applications response
+// (?version=2). Apps whose query failed are listed in Errors and, when a
+// last known value exists, also appear in Applications marked Stale.
+type ApplicationsResponse struct {
+	Version      int                `json:"version"`
+	Network      string             `json:"network"`
+	Applications []Application      `json:"applications"`
+	Errors       []ApplicationError `json:"errors"`
+	FetchedAt    time.Time          `json:"fetched_at"`
+	Height       int64              `json:"height,omitempty"` // chain height of the freshest app
+}
+
+// ApplicationsResponseV3 is ApplicationsResponse with amounts as uPOKT
+// strings (?version=3).
+type ApplicationsResponseV3 struct {
+	ApplicationsResponse
+	Applications []ApplicationV3 `json:"applications"`
+}
+
+// V3 converts the response to version 3, writing amounts in denom.
+func (r ApplicationsResponse) V3(denom string) ApplicationsResponseV3 {
+	apps := make([]ApplicationV3, len(r.Applications))
+	for i, app := range r.Applications {
+		apps[i] = app.V3(denom)
+	}
+	r.Version = AmountsVersion
+	return ApplicationsResponseV3{ApplicationsResponse: r, Applications: apps}
+}
+
+// ApplicationV3 is Application with amounts as uPOKT strings.
+type ApplicationV3 struct {
+	Application
+	Stake         Amount          `json:"stake"`
+	LiquidBalance Amount          `json:"liquid_balance"`
+	FeeAllowance  *FeeAllowanceV3 `json:"fee_allowance,omitempty"`
+}
+
+// V3 converts the application to version 3, writing amounts in denom.
+func (a Application) V3(denom string) ApplicationV3 {
+	v3 := ApplicationV3{
+		Application:   a,
+		Stake:         NewAmountOf(a.Stake, denom),
+		LiquidBalance: NewAmountOf(a.LiquidBalance, denom),
+	}
+	if a.FeeAllowance != nil {
+		allowance := a.FeeAllowance.V3(denom)
+		v3.FeeAllowance = &allowance
+	}
+	return v3
+}
+
+// ApplicationError reports an application whose query failed.
+type ApplicationError struct {
+	Address string `json:"address"`
+	Error   string `json:"error"`
+	Stale   bool   `json:"stale"` // a stale value is included in Applications
+}
+
+// DiscoveryReport compares the applications delegated on-chain to a
+// network's gateways with the ones in its config.
+type DiscoveryReport struct {
+	Network      string          `json:"network"`
+	Gateways     []string        `json:"gateways"`
+	Untracked    []DiscoveredApp `json:"untracked"`     // delegated to a gateway, not in config
+	NotDelegated []DiscoveredApp `json:"not_delegated"` // in config, not delegated to any gateway
+	CheckedAt    time.Time       `json:"checked_at"`
+}
+
+// DiscoveredApp is an application found by discovery. Error is set when a
+// tracked app could not be queried, e.g. because it is no longer staked.
+type DiscoveredApp struct {
+	Address   string `json:"address"`
+	ServiceID string `json:"service_id,omitempty"`
+	Stake     int64  `json:"stake"`
+	Gateway   string `json:"gateway,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// AdoptRequest is the JSON body for adopting discovered applications.
+type AdoptRequest struct {
+	Addresses []string `json:"addresses"`
+}
+
+// AdoptResponse reports which applications were added to config.
+type AdoptResponse struct {
+	Network string         `json:"network"`
+	Adopted []string       `json:"adopted"`
+	Skipped []AdoptSkipped `json:"skipped"`
+}
+
+// AdoptSkipped is an application that was not adopted, and why.
+type AdoptSkipped struct {
+	Address string `json:"address"`
+	Reason  string `json:"reason"`
+}
+
+// FeeAllowance is a feegrant allowance from Granter (the bank) to Grantee.
+// SpendLimit is the remaining allowance in uPOKT; nil means unlimited.
+type FeeAllowance struct {
+	Granter    string     `json:"granter"`
+	Grantee    string     `json:"grantee"`
+	SpendLimit *int64     `json:"spend_limit,omitempty"`
+	Expiration *time.Time `json:"expiration,omitempty"`
+	Active     bool       `json:"active"`
+}
+
+// FeeAllowanceV3 is FeeAllowance with the spend limit as a uPOKT string.
+type FeeAllowanceV3 struct {
+	FeeAllowance
+	SpendLimit *Amount `json:"spend_limit,omitempty"`
+}
+
+// V3 converts the allowance to version 3, writing the limit in denom.
+func (f FeeAllowance) V3(denom string) FeeAllowanceV3 {
+	v3 := FeeAllowanceV3{FeeAllowance: f}
+	if f.SpendLimit != nil {
+		limit := NewAmountOf(*f.SpendLimit, denom)
+		v3.SpendLimit = &limit
+	}
+	return v3
+}
+
+// FeeGrantRequest is the JSON body for creating a fee grant.
+type FeeGrantRequest struct {
+	SpendLimit     Amount `json:"spend_limit"`
+	ExpirationDays int    `json:"expiration_days,omitempty"` // 0 = no expiration
+}
+
+// AuthzGrant is an authz grant letting Grantee submit MsgTypeURL for Granter.
+type AuthzGrant struct {
+	Granter    string     `json:"granter"`
+	Grantee    string     `json:"grantee"`
+	MsgTypeURL string     `json:"msg_type_url"`
+	Expiration *time.Time `json:"expiration,omitempty"`
+	Active     bool       `json:"active"`
+}
+
+// AuthzGrantRequest is the optional JSON body for creating an authz grant.
+type AuthzGrantRequest struct {
+	ExpirationDays int `json:"expiration_days,omitempty"` // 0 = no expiration
 }
 
 // BankAccount represents a bank account balance on a network.
 type BankAccount struct {
-	Address string `json:"address"`
-	Balance int64  `json:"balance"`
-	Network string `json:"network"`
+	Address  string `json:"address"`
+	Balance  int64  `json:"balance"` // the network's base denom
+	Denom    string `json:"denom"`
+	Balances []Coin `json:"balances"` // every denom held
+	Network  string `json:"network"`
+}
+
+// BankAccountV3 is BankAccount with the balance as a uPOKT string.
+type BankAccountV3 struct {
+	BankAccount
+	Balance Amount `json:"balance"`
+}
+
+// V3 converts the account to version 3, writing the balance in its Denom.
+func (b BankAccount) V3() BankAccountV3 {
+	return BankAccountV3{BankAccount: b, Balance: NewAmountOf(b.Balance, b.Denom)}
 }
 
 // StakeRequest is the JSON body for upstake/fund POST endpoints.
 type StakeRequest struct {
-	Amount float64 `json:"amount"` // In POKT
+	Amount Amount `json:"amount"`
 }
 
 // TransactionResponse is returned after a write transaction.
+// PendingID is set instead of TxHash when the transaction was generated
+// unsigned and is waiting for an offline signature.
 type TransactionResponse struct {
-	TxHash  string `json:"tx_hash"`
-	Success bool   `json:"success"`
-	Message string `json:"message,omitempty"`
+	TxHash    string `json:"tx_hash"`
+	Success   bool   `json:"success"`
+	Message   string `json:"message,omitempty"`
+	PendingID string `json:"pending_id,omitempty"`
+}
+
+// Pending transaction statuses.
+const (
+	PendingAwaitingSignature = "awaiting_signature"
+	PendingBroadcast         = "broadcast"
+	PendingConfirmed         = "confirmed"
+	PendingFailed            = "failed"
+	PendingExpired           = "expired" // not signed before ExpiresAt
+)
+
+// PendingTx is an unsigned transaction waiting to be signed offline and broadcast.
+type PendingTx struct {
+	ID         string `json:"id"`
+	Network    string `json:"network"`
+	Kind       string `json:"kind"` // e.g. "fund"
+	From       string `json:"from"`
+	To         string `json:"to"`
+	Amount     int64  `json:"amount"` // uPOKT
+	UnsignedTx string `json:"unsigned_tx"`
+	Status     string `json:"status"`
+
+	// Multisig banks collect one partial signature per signer until
+	// Threshold is reached. Signatures maps signer address -> signature JSON.
+	Threshold      int               `json:"threshold,omitempty"`
+	Signers        []string          `json:"signers,omitempty"`
+	Signatures     map[string]string `json:"signatures,omitempty"`
+	MissingSigners []string          `json:"missing_signers,omitempty"`
+
+	TxHash      string     `json:"tx_hash,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"` // signatures are no longer accepted after it
+	BroadcastAt *time.Time `json:"broadcast_at,omitempty"`
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty"`
 }
 
 // ErrorResponse is the standard error envelope.
@@ -39,18 +276,120 @@ type ErrorResponse struct {
 // API response structures for Pocket Network REST endpoints.
 
 type APIApplicationResponse struct {
-	Application struct {
-		Address                   string          `json:"address"`
-		Stake                     *Coin           `json:"stake"`
-		ServiceConfigs            []ServiceConfig `json:"service_configs"`
-		DelegateeGatewayAddresses []string        `json:"delegatee_gateway_addresses"`
-	} `json:"application"`
+	Application APIApplication `json:"application"`
+}
+
+// APIApplicationsResponse is one page of the list-applications query.
+type APIApplicationsResponse struct {
+	Applications []APIApplication `json:"applications"`
+	Pagination   APIPagination    `json:"pagination"`
+}
+
+// APIApplication is an application as returned by the poktroll queries.
+// Heights are uint64 values, which the REST gateway encodes as strings.
+type APIApplication struct {
+	Address                   string                                `json:"address"`
+	Stake                     *Coin                                 `json:"stake"`
+	ServiceConfigs            []ServiceConfig                       `json:"service_configs"`
+	DelegateeGatewayAddresses []string                              `json:"delegatee_gateway_addresses"`
+	PendingUndelegations      map[string]APIUndelegatingGatewayList `json:"pending_undelegations"`
+	UnstakeSessionEndHeight   string                                `json:"unstake_session_end_height"`
+	PendingTransfer           *APIPendingTransfer                   `json:"pending_transfer"`
+}
+
+// APIUndelegatingGatewayList is the value of pending_undelegations, keyed
+// by session end height.
+type APIUndelegatingGatewayList struct {
+	GatewayAddresses []string `json:"gateway_addresses"`
+}
+
+// APIPendingTransfer is an application's pending stake transfer.
+type APIPendingTransfer struct {
+	DestinationAddress string `json:"destination_address"`
+	SessionEndHeight   string `json:"session_end_height"`
+}
+
+// APIPagination is the Cosmos page response; NextKey is empty on the last
+// page.
+type APIPagination struct {
+	NextKey string `json:"next_key"`
+	Total   string `json:"total"`
+}
+
+// APITxResponse is the response from the tx-by-hash query endpoint.
+type APITxResponse struct {
+	TxResponse struct {
+		Height string `json:"height"`
+		TxHash string `json:"txhash"`
+		Code   uint32 `json:"code"`
+		RawLog string `json:"raw_log"`
+	} `json:"tx_response"`
+}
+
+// APIAccountResponse is the response from the auth account query endpoint.
+type APIAccountResponse struct {
+	Account struct {
+		Type          string `json:"@type"`
+		Address       string `json:"address"`
+		AccountNumber string `json:"account_number"`
+		Sequence      string `json:"sequence"`
+	} `json:"account"`
+}
+
+// APIGrantsResponse is the response from the authz grants query endpoints.
+// The by-grantee listing also fills in each grant's granter and grantee.
+type APIGrantsResponse struct {
+	Grants     []APIGrant    `json:"grants"`
+	Pagination APIPagination `json:"pagination"`
+}
+
+// APIGrant is one authz grant.
+type APIGrant struct {
+	Granter       string `json:"granter"`
+	Grantee       string `json:"grantee"`
+	Authorization struct {
+		Type string `json:"@type"`
+		Msg  string `json:"msg"`
+	} `json:"authorization"`
+	Expiration *time.Time `json:"expiration"`
+}
+
+// APIFeeAllowanceResponse is the response from the feegrant allowance endpoint.
+type APIFeeAllowanceResponse struct {
+	Allowance APIFeeGrant `json:"allowance"`
+}
+
+// APIFeeAllowancesResponse is the response from the feegrant issued
+// allowances endpoint.
+type APIFeeAllowancesResponse struct {
+	Allowances []APIFeeGrant `json:"allowances"`
+	Pagination APIPagination `json:"pagination"`
+}
+
+// APIFeeGrant is one granter→grantee fee allowance.
+type APIFeeGrant struct {
+	Granter   string          `json:"granter"`
+	Grantee   string          `json:"grantee"`
+	Allowance APIFeeAllowance `json:"allowance"`
+}
+
+// APIFeeAllowance covers BasicAllowance directly and PeriodicAllowance /
+// AllowedMsgAllowance through their nested basic/allowance fields.
+type APIFeeAllowance struct {
+	Type       string           `json:"@type"`
+	SpendLimit []Coin           `json:"spend_limit"`
+	Expiration *time.Time       `json:"expiration"`
+	Basic      *APIFeeAllowance `json:"basic"`
+	Allowance  *APIFeeAllowance `json:"allowance"`
 }
 
 type APIBalanceResponse struct {
-	Balances []Coin `json:"balances"`
+	Balances   []Coin        `json:"balances"`
+	Pagination APIPagination `json:"pagination"`
 }
 
+// Coin is an amount of one denomination, with the amount as an integer
+// string in that denom's base unit.
 type Coin struct {
 	Denom  string `json:"denom"`
 	Amount string `json:"amount"`
@@ -68,9 +407,9 @@ type Service struct {
 
 // NewStakeRequest is the JSON body for staking a new application.
 type NewStakeRequest struct {
-	Address   string  `json:"address"`
-	ServiceID string  `json:"service_id"`
-	Amount    float64 `json:"amount"` // In POKT
+	Address   string `json:"address"`
+	ServiceID string `json:"service_id"`
+	Amount    Amount `json:"amount"`
 }
 
 // ServiceInfo represents an available service on the network.
@@ -79,9 +418,107 @@ type ServiceInfo struct {
 	Name string `json:"name,omitempty"`
 }
 
+// NodeStatus is a node's view of the chain: its latest block and whether
+// it is still syncing.
+type NodeStatus struct {
+	Height     int64     `json:"height"`
+	CatchingUp bool      `json:"catching_up"`
+	BlockTime  time.Time `json:"block_time"`
+}
+
+// NetworksResponseVersion is the /api/networks version that reports chain
+// status instead of bare names.
+const NetworksResponseVersion = 2
+
+// NetworksResponse is the versioned /api/networks response (?version=2).
+type NetworksResponse struct {
+	Version  int             `json:"version"`
+	Networks []NetworkStatus `json:"networks"`
+}
+
+// NetworkStatus is a network's chain status, taken from its highest
+// endpoint at the last probe. Height is 0 until an endpoint answers.
+type NetworkStatus struct {
+	Name       string     `json:"name"`
+	Height     int64      `json:"height"`
+	CatchingUp bool       `json:"catching_up"`
+	BlockTime  *time.Time `json:"block_time,omitempty"`
+	CheckedAt  *time.Time `json:"checked_at,omitempty"`
+	Stalled    bool       `json:"stalled"` // no endpoint's height has advanced within the stall timeout
+}
+
+// ChainParams are the on-chain module parameters of a network that bound
+// what SAM may do, such as the minimum application stake.
+type ChainParams struct {
+	Network     string            `json:"network"`
+	Application ApplicationParams `json:"application"`
+	Shared      SharedParams      `json:"shared"`
+	Tokenomics  TokenomicsParams  `json:"tokenomics"`
+	FetchedAt   time.Time         `json:"fetched_at"`
+}
+
+// ApplicationParams are the application module's parameters.
+type ApplicationParams struct {
+	MinStake             int64 `json:"min_stake"` // base denom
+	MaxDelegatedGateways int64 `json:"max_delegated_gateways"`
+}
+
+// SharedParams are the shared module's session and unbonding parameters, in
+// blocks unless named otherwise.
+type SharedParams struct {
+	NumBlocksPerSession                int64 `json:"num_blocks_per_session"`
+	GracePeriodEndOffsetBlocks         int64 `json:"grace_period_end_offset_blocks"`
+	ClaimWindowOpenOffsetBlocks        int64 `json:"claim_window_open_offset_blocks"`
+	ClaimWindowCloseOffsetBlocks       int64 `json:"claim_window_close_offset_blocks"`
+	ProofWindowOpenOffsetBlocks        int64 `json:"proof_window_open_offset_blocks"`
+	ProofWindowCloseOffsetBlocks       int64 `json:"proof_window_close_offset_blocks"`
+	SupplierUnbondingPeriodSessions    int64 `json:"supplier_unbonding_period_sessions"`
+	ApplicationUnbondingPeriodSessions int64 `json:"application_unbonding_period_sessions"`
+	ComputeUnitsToTokensMultiplier     int64 `json:"compute_units_to_tokens_multiplier"`
+}
+
+// TokenomicsParams are the tokenomics module's inflation parameters.
+type TokenomicsParams struct {
+	MintAllocationPercentages map[string]float64 `json:"mint_allocation_percentages"`
+	DaoRewardAddress          string             `json:"dao_reward_address"`
+	GlobalInflationPerClaim   float64            `json:"global_inflation_per_claim"`
+}
+
+// APIApplicationParamsResponse is the application module params query
+// response.
+type APIApplicationParamsResponse struct {
+	Params struct {
+		MaxDelegatedGateways string `json:"max_delegated_gateways"`
+		MinStake             *Coin  `json:"min_stake"`
+	} `json:"params"`
+}
+
+// APISharedParamsResponse is the shared module params query response.
+// Integers are encoded as strings.
+type APISharedParamsResponse struct {
+	Params struct {
+		NumBlocksPerSession                string `json:"num_blocks_per_session"`
+		GracePeriodEndOffsetBlocks         string `json:"grace_period_end_offset_blocks"`
+		ClaimWindowOpenOffsetBlocks        string `json:"claim_window_open_offset_blocks"`
+		ClaimWindowCloseOffsetBlocks       string `json:"claim_window_close_offset_blocks"`
+		ProofWindowOpenOffsetBlocks        string `json:"proof_window_open_offset_blocks"`
+		ProofWindowCloseOffsetBlocks       string `json:"proof_window_close_offset_blocks"`
+		SupplierUnbondingPeriodSessions    string `json:"supplier_unbonding_period_sessions"`
+		ApplicationUnbondingPeriodSessions string `json:"application_unbonding_period_sessions"`
+		ComputeUnitsToTokensMultiplier     string `json:"compute_units_to_tokens_multiplier"`
+	} `json:"params"`
+}
+
+// APITokenomicsParamsResponse is the tokenomics module params query
+// response.
+type APITokenomicsParamsResponse struct {
+	Params TokenomicsParams `json:"params"`
+}
+
 // APIServicesResponse is the response from the services query endpoint.
 type APIServicesResponse struct {
-	Service []APIServiceEntry `json:"service"`
+	Service    []APIServiceEntry `json:"service"`
+	Pagination APIPagination     `json:"pagination"`
 }
 
 // APIServiceEntry represents a single service from the API.
@@ -97,11 +534,29 @@ type AutoTopUpConfig struct {
 	TargetAmount     int64 `json:"target_amount"`     // uPOKT
 }
 
-// AutoTopUpRequest is the JSON body from the frontend (POKT values).
+// AutoTopUpRequest is the JSON body from the frontend.
 type AutoTopUpRequest struct {
-	Enabled          bool    `json:"enabled"`
-	TriggerThreshold float64 `json:"trigger_threshold"` // POKT
-	TargetAmount     float64 `json:"target_amount"`     // POKT
+	Enabled          bool   `json:"enabled"`
+	TriggerThreshold Amount `json:"trigger_threshold"`
+	TargetAmount     Amount `json:"target_amount"`
+}
+
+// SweepConfig is the stored per-app sweep policy (uPOKT).
+type SweepConfig struct {
+	Enabled bool  `json:"enabled"`
+	Floor   int64 `json:"floor"` // uPOKT left on the app after a sweep
+}
+
+// SweepPolicyRequest is the JSON body for configuring a sweep policy.
+type SweepPolicyRequest struct {
+	Enabled bool   `json:"enabled"`
+	Floor   Amount `json:"floor"`
+}
+
+// SweepRequest is the optional JSON body for an on-demand sweep.
+// When Floor is nil the app's stored policy floor (or zero) is used.
+type SweepRequest struct {
+	Floor *Amount `json:"floor,omitempty"`
 }
 
 // AutoTopUpEvent records a single auto-top-up action.
@@ -113,7 +568,42 @@ type AutoTopUpEvent struct {
 	TargetAmount  int64     `json:"target_amount"`
 	FundTxHash    string    `json:"fund_tx_hash,omitempty"`
 	StakeTxHash   string    `json:"stake_tx_hash,omitempty"`
+	SweptAmount   int64     `json:"swept_amount,omitempty"`
+	SweepTxHash   string    `json:"sweep_tx_hash,omitempty"`
+	PendingID     string    `json:"pending_id,omitempty"`
 	Success       bool      `json:"success"`
 	Error         string    `json:"error,omitempty"`
 	Phase         string    `json:"phase"`
 }
+
+// AppHistoryPoint is an application's stake and liquid balance at Time
+// (base denom).
+type AppHistoryPoint struct {
+	Time          time.Time `json:"time"`
+	Stake         int64     `json:"stake"`
+	LiquidBalance int64     `json:"liquid_balance"`
+}
+
+// BankHistoryPoint is a bank balance at Time (base denom).
+type BankHistoryPoint struct {
+	Time    time.Time `json:"time"`
+	Balance int64     `json:"balance"`
+}
+
+// AppHistoryResponse is the /api/applications/{address}/history response.
+type AppHistoryResponse struct {
+	Network string            `json:"network"`
+	Address string            `json:"address"`
+	From    time.Time         `json:"from"`
+	To      time.Time         `json:"to"`
+	Points  []AppHistoryPoint `json:"points"` // oldest first
+}
+
+// BankHistoryResponse is the /api/bank/history response.
+type BankHistoryResponse struct {
+	Network string             `json:"network"`
+	Address string             `json:"address"`
+	From    time.Time          `json:"from"`
+	To      time.Time          `json:"to"`
+	Points  []BankHistoryPoint `json:"points"` // oldest first
+}
@@ -0,0 +1,113 @@
+package pendingtx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/pokt-network/sam/internal/models"
+	"github.com/pokt-network/sam/internal/pubkey"
+)
+
+// txJSON is the subset of a Cosmos SDK JSON transaction needed to compare a
+// signed transaction against the unsigned one SAM generated.
+type txJSON struct {
+	Body     json.RawMessage `json:"body"`
+	AuthInfo struct {
+		SignerInfos []struct {
+			PublicKey *pubkey.Any `json:"public_key"`
+		} `json:"signer_infos"`
+		Fee json.RawMessage `json:"fee"`
+	} `json:"auth_info"`
+	Signatures []string `json:"signatures"`
+}
+
+// CheckSigned verifies that signed is a signed copy of the pending
+// transaction tx: it must carry at least one signature, its body (messages,
+// memo, timeout) and fee must be identical, and it must be signed by the
+// bank — for a multisig bank, by the configured threshold and signers — so
+// an uploaded file can't swap in a different transaction, fee or signer.
+func CheckSigned(tx models.PendingTx, signed []byte) error {
+	var u, s txJSON
+	if err := json.Unmarshal([]byte(tx.UnsignedTx), &u); err != nil {
+		return fmt.Errorf("failed to parse unsigned transaction: %w", err)
+	}
+	if err := json.Unmarshal(signed, &s); err != nil {
+		return fmt.Errorf("failed to parse signed transaction: %w", err)
+	}
+
+	if len(s.Signatures) == 0 {
+		return errors.New("signed transaction has no signatures")
+	}
+
+	if err := sameJSON("body", u.Body, s.Body); err != nil {
+		return err
+	}
+	if err := sameJSON("fee", u.AuthInfo.Fee, s.AuthInfo.Fee); err != nil {
+		return err
+	}
+
+	if len(s.AuthInfo.SignerInfos) != 1 || s.AuthInfo.SignerInfos[0].PublicKey == nil {
+		return errors.New("signed transaction must have exactly one signer with a public key")
+	}
+	key := s.AuthInfo.SignerInfos[0].PublicKey
+
+	if tx.Threshold > 0 {
+		return checkMultisigKey(key, tx.Threshold, tx.Signers)
+	}
+
+	addr, err := pubkey.Address(key.Type, key.Key)
+	if err != nil {
+		return fmt.Errorf("invalid signer public key: %w", err)
+	}
+	if addr != tx.From {
+		return fmt.Errorf("signed transaction is signed by %s, not the bank %s", addr, tx.From)
+	}
+	return nil
+}
+
+// sameJSON reports an error unless the unsigned and signed values of a
+// transaction field decode to the same JSON.
+func sameJSON(field string, unsigned, signed json.RawMessage) error {
+	var uv, sv any
+	if err := json.Unmarshal(unsigned, &uv); err != nil {
+		return fmt.Errorf("unsigned transaction has no %s: %w", field, err)
+	}
+	if err := json.Unmarshal(signed, &sv); err != nil {
+		return fmt.Errorf("signed transaction has no %s: %w", field, err)
+	}
+	if !reflect.DeepEqual(uv, sv) {
+		return fmt.Errorf("signed transaction %s does not match the pending transaction", field)
+	}
+	return nil
+}
+
+// checkMultisigKey verifies that key is a multisig public key with the
+// pending transaction's threshold whose members are exactly its signers.
+func checkMultisigKey(key *pubkey.Any, threshold int, signers []string) error {
+	if key.Type != pubkey.MultisigType {
+		return fmt.Errorf("signed transaction is not signed by a multisig key (%s)", key.Type)
+	}
+	if key.Threshold != threshold {
+		return fmt.Errorf("multisig key threshold is %d, want %d", key.Threshold, threshold)
+	}
+
+	members := make([]string, 0, len(key.PublicKeys))
+	for _, member := range key.PublicKeys {
+		addr, err := pubkey.Address(member.Type, member.Key)
+		if err != nil {
+			return fmt.Errorf("invalid multisig member public key: %w", err)
+		}
+		members = append(members, addr)
+	}
+
+	want := slices.Clone(signers)
+	slices.Sort(members)
+	slices.Sort(want)
+	if !slices.Equal(members, want) {
+		return fmt.Errorf("multisig key members %v do not match the configured signers %v", members, want)
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+// Package pendingtx stores unsigned transactions that wait for an offline
+// signature, and tracks them through broadcast and confirmation.
+package pendingtx
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pokt-network/sam/internal/jsonfile"
+	"github.com/pokt-network/sam/internal/models"
+)
+
+// DefaultTTL is how long an unsigned transaction waits for its signatures
+// before it expires.
+const DefaultTTL = 24 * time.Hour
+
+// StoreData maps pending transaction ID -> transaction.
+type StoreData map[string]models.PendingTx
+
+// Store provides thread-safe persistence for pending transactions.
+type Store struct {
+	TTL time.Duration // awaiting transactions expire this long after creation
+
+	mu   sync.RWMutex
+	path string
+	data StoreData
+}
+
+// NewStore loads or creates a pending transaction file.
+func NewStore(path string) (*Store, error) {
+	s := &Store{
+		TTL:  DefaultTTL,
+		path: path,
+		data: make(StoreData),
+	}
+
+	if err := jsonfile.Load(path, &s.data); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Add assigns an ID, creation time and initial status to tx and persists it.
+func (s *Store) Add(tx models.PendingTx) (models.PendingTx, error) {
+	id, err := newID()
+	if err != nil {
+		return models.PendingTx{}, err
+	}
+
+	tx.ID = id
+	tx.CreatedAt = time.Now()
+	expiresAt := tx.CreatedAt.Add(s.TTL)
+	tx.ExpiresAt = &expiresAt
+	tx.Status = models.PendingAwaitingSignature
+	tx.MissingSigners = missingSigners(tx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data[id] = tx
+	if err := jsonfile.WriteAtomic(s.path, s.data); err != nil {
+		delete(s.data, id)
+		return models.PendingTx{}, err
+	}
+	return tx, nil
+}
+
+// Get returns a pending transaction by ID.
+func (s *Store) Get(id string) (models.PendingTx, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tx, ok := s.data[id]
+	return tx, ok
+}
+
+// List returns the pending transactions for a network, newest first.
+// An empty network returns transactions for every network.
+func (s *Store) List(network string) []models.PendingTx {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]models.PendingTx, 0, len(s.data))
+	for _, tx := range s.data {
+		if network == "" || tx.Network == network {
+			result = append(result, tx)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	return result
+}
+
+// WithStatus returns every pending transaction in the given status.
+func (s *Store) WithStatus(status string) []models.PendingTx {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []models.PendingTx
+	for _, tx := range s.data {
+		if tx.Status == status {
+			result = append(result, tx)
+		}
+	}
+	return result
+}
+
+// Outstanding returns an awaiting or broadcast transaction of the given kind
+// to an address, so callers can avoid generating duplicates. Expired
+// transactions are not outstanding, even before Expire marks them.
+func (s *Store) Outstanding(network, kind, to string) (models.PendingTx, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	for _, tx := range s.data {
+		if tx.Network != network || tx.Kind != kind || tx.To != to {
+			continue
+		}
+		if tx.Status == models.PendingBroadcast || tx.Status == models.PendingAwaitingSignature && !s.expired(tx, now) {
+			return tx, true
+		}
+	}
+	return models.PendingTx{}, false
+}
+
+// Expire marks every awaiting transaction past its expiry as expired,
+// persists the change and returns them.
+func (s *Store) Expire(now time.Time) ([]models.PendingTx, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var expired []models.PendingTx
+	for id, tx := range s.data {
+		if tx.Status != models.PendingAwaitingSignature || !s.expired(tx, now) {
+			continue
+		}
+		tx.Status = models.PendingExpired
+		tx.Error = "not signed before it expired"
+		s.data[id] = tx
+		expired = append(expired, tx)
+	}
+	if len(expired) == 0 {
+		return nil, nil
+	}
+	return expired, jsonfile.WriteAtomic(s.path, s.data)
+}
+
+// expired reports whether tx is past its expiry at now. Transactions stored
+// without one expire TTL after creation.
+func (s *Store) expired(tx models.PendingTx, now time.Time) bool {
+	expiresAt := tx.CreatedAt.Add(s.TTL)
+	if tx.ExpiresAt != nil {
+		expiresAt = *tx.ExpiresAt
+	}
+	return now.After(expiresAt)
+}
+
+// Update applies fn to the transaction with the given ID and persists the result.
+func (s *Store) Update(id string, fn func(tx *models.PendingTx) error) (models.PendingTx, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, ok := s.data[id]
+	if !ok {
+		return models.PendingTx{}, fmt.Errorf("pending transaction %q not found", id)
+	}
+
+	if err := fn(&tx); err != nil {
+		return models.PendingTx{}, err
+	}
+
+	prev := s.data[id]
+	s.data[id] = tx
+	if err := jsonfile.WriteAtomic(s.path, s.data); err != nil {
+		s.data[id] = prev
+		return models.PendingTx{}, err
+	}
+	return tx, nil
+}
+
+// AddSignature records a multisig member's partial signature. It returns the
+// updated transaction; callers compare len(Signatures) with Threshold to
+// decide when to combine.
+func (s *Store) AddSignature(id, signer, signature string) (models.PendingTx, error) {
+	return s.Update(id, func(tx *models.PendingTx) error {
+		if tx.Threshold == 0 {
+			return fmt.Errorf("pending transaction %q is not a multisig transaction", id)
+		}
+		if tx.Status != models.PendingAwaitingSignature {
+			return fmt.Errorf("pending transaction %q is %s", id, tx.Status)
+		}
+		if !slices.Contains(tx.Signers, signer) {
+			return fmt.Errorf("%s is not a signer of this multisig", signer)
+		}
+
+		sigs := make(map[string]string, len(tx.Signatures)+1)
+		for k, v := range tx.Signatures {
+			sigs[k] = v
+		}
+		sigs[signer] = signature
+		tx.Signatures = sigs
+		tx.MissingSigners = missingSigners(*tx)
+		return nil
+	})
+}
+
+// Delete removes a pending transaction and persists to disk.
+func (s *Store) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.data, id)
+	return jsonfile.WriteAtomic(s.path, s.data)
+}
+
+// missingSigners lists the multisig members that haven't signed yet, in
+// configured order.
+func missingSigners(tx models.PendingTx) []string {
+	var missing []string
+	for _, signer := range tx.Signers {
+		if _, ok := tx.Signatures[signer]; !ok {
+			missing = append(missing, signer)
+		}
+	}
+	return missing
+}
+
+func newID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate pending transaction ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
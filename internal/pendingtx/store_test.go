@@ -0,0 +1,271 @@
+package pendingtx
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pokt-network/sam/internal/models"
+)
+
+const unsignedTx = `{"body":{"messages":[{"@type":"/cosmos.bank.v1beta1.MsgSend","amount":[{"denom":"upokt","amount":"100"}]}],"memo":""},"auth_info":{"signer_infos":[],"fee":{"amount":[{"denom":"upokt","amount":"1"}],"gas_limit":"200000","payer":"","granter":""}},"signatures":[]}`
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	s, err := NewStore(filepath.Join(t.TempDir(), "pending.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestStore_AddAndGet(t *testing.T) {
+	s := newTestStore(t)
+
+	tx, err := s.Add(models.PendingTx{Network: "pocket", Kind: "fund", To: "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", Amount: 100, UnsignedTx: unsignedTx})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if len(tx.ID) != 16 {
+		t.Errorf("ID = %q, want 16 hex characters", tx.ID)
+	}
+	if tx.Status != models.PendingAwaitingSignature {
+		t.Errorf("Status = %q, want %q", tx.Status, models.PendingAwaitingSignature)
+	}
+
+	got, ok := s.Get(tx.ID)
+	if !ok {
+		t.Fatal("Get() should find added transaction")
+	}
+	if got.Amount != 100 {
+		t.Errorf("Amount = %d, want 100", got.Amount)
+	}
+
+	if len(s.List("pocket")) != 1 || len(s.List("other")) != 0 {
+		t.Error("List() should filter by network")
+	}
+}
+
+func TestStore_Outstanding(t *testing.T) {
+	s := newTestStore(t)
+	addr := "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+
+	if _, ok := s.Outstanding("pocket", "fund", addr); ok {
+		t.Fatal("empty store should have no outstanding transactions")
+	}
+
+	tx, _ := s.Add(models.PendingTx{Network: "pocket", Kind: "fund", To: addr, UnsignedTx: unsignedTx})
+	if _, ok := s.Outstanding("pocket", "fund", addr); !ok {
+		t.Error("awaiting transaction should be outstanding")
+	}
+
+	s.Update(tx.ID, func(p *models.PendingTx) error {
+		p.Status = models.PendingConfirmed
+		return nil
+	})
+	if _, ok := s.Outstanding("pocket", "fund", addr); ok {
+		t.Error("confirmed transaction should not be outstanding")
+	}
+}
+
+func TestStore_Expire(t *testing.T) {
+	s := newTestStore(t)
+	s.TTL = time.Hour
+	addr := "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+
+	tx, _ := s.Add(models.PendingTx{Network: "pocket", Kind: "fund", To: addr, UnsignedTx: unsignedTx})
+	if tx.ExpiresAt == nil || !tx.ExpiresAt.Equal(tx.CreatedAt.Add(time.Hour)) {
+		t.Fatalf("ExpiresAt = %v, want an hour after creation", tx.ExpiresAt)
+	}
+
+	// Nobody signed: past the TTL the fund no longer blocks a new one.
+	s.Update(tx.ID, func(p *models.PendingTx) error {
+		past := time.Now().Add(-time.Minute)
+		p.ExpiresAt = &past
+		return nil
+	})
+	if _, ok := s.Outstanding("pocket", "fund", addr); ok {
+		t.Error("expired transaction should not be outstanding")
+	}
+
+	expired, err := s.Expire(time.Now())
+	if err != nil || len(expired) != 1 || expired[0].ID != tx.ID {
+		t.Fatalf("Expire() = %+v, %v; want the fund", expired, err)
+	}
+	if got, _ := s.Get(tx.ID); got.Status != models.PendingExpired {
+		t.Errorf("status = %q, want %q", got.Status, models.PendingExpired)
+	}
+	if again, _ := s.Expire(time.Now()); len(again) != 0 {
+		t.Errorf("second Expire() = %+v, want none", again)
+	}
+}
+
+func TestStore_AddSignature(t *testing.T) {
+	s := newTestStore(t)
+	signerA := "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	signerC := "pokt1cccccccccccccccccccccccccccccccccccccc"
+
+	tx, err := s.Add(models.PendingTx{
+		Network:    "pocket",
+		Kind:       "fund",
+		UnsignedTx: unsignedTx,
+		Threshold:  2,
+		Signers:    []string{signerA, signerC},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tx.MissingSigners) != 2 {
+		t.Fatalf("MissingSigners = %v, want both signers", tx.MissingSigners)
+	}
+
+	if _, err := s.AddSignature(tx.ID, "pokt1dddddddddddddddddddddddddddddddddddddd", `{}`); err == nil {
+		t.Error("AddSignature() should reject a non-member")
+	}
+
+	got, err := s.AddSignature(tx.ID, signerA, `{"signatures":[]}`)
+	if err != nil {
+		t.Fatalf("AddSignature() error = %v", err)
+	}
+	if len(got.Signatures) != 1 {
+		t.Errorf("Signatures = %d, want 1", len(got.Signatures))
+	}
+	if len(got.MissingSigners) != 1 || got.MissingSigners[0] != signerC {
+		t.Errorf("MissingSigners = %v, want [%s]", got.MissingSigners, signerC)
+	}
+}
+
+func TestStore_AddSignature_NotMultisig(t *testing.T) {
+	s := newTestStore(t)
+	tx, _ := s.Add(models.PendingTx{Network: "pocket", Kind: "fund", UnsignedTx: unsignedTx})
+
+	if _, err := s.AddSignature(tx.ID, "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", `{}`); err == nil {
+		t.Error("AddSignature() should reject a single-signer transaction")
+	}
+}
+
+func TestStore_Persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pending.json")
+	s1, err := NewStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, _ := s1.Add(models.PendingTx{Network: "pocket", Kind: "fund", UnsignedTx: unsignedTx})
+
+	s2, err := NewStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s2.Get(tx.ID); !ok {
+		t.Error("pending transaction not persisted to disk")
+	}
+}
+
+// Test keys and the addresses they derive to.
+const (
+	bankKey   = "ApUOHN/LEz1gJBCf1In3NO60UCQY5TjChIHyK84nbySM"
+	bankAddr  = "pokt10s4mg25tu6termrk8egltfyme4q7sg3hy949ey"
+	memberKey = "AxERERERERERERERERERERERERERERERERERERERERER"
+	member    = "pokt1mn00lxds8x62dqmtz780ysdrts2jx3qtpc2jy5"
+)
+
+// signedTx builds a signed copy of unsignedTx with the given fee amount and
+// signer public key JSON.
+func signedTx(feeAmount, publicKey string) string {
+	return `{"body":{"memo":"","messages":[{"@type":"/cosmos.bank.v1beta1.MsgSend","amount":[{"denom":"upokt","amount":"100"}]}]},` +
+		`"auth_info":{"signer_infos":[{"public_key":` + publicKey + `,"mode_info":{},"sequence":"3"}],` +
+		`"fee":{"amount":[{"denom":"upokt","amount":"` + feeAmount + `"}],"gas_limit":"200000","payer":"","granter":""}},` +
+		`"signatures":["c2ln"]}`
+}
+
+func TestCheckSigned(t *testing.T) {
+	bank := `{"@type":"/cosmos.crypto.secp256k1.PubKey","key":"` + bankKey + `"}`
+	multisig := `{"@type":"/cosmos.crypto.multisig.LegacyAminoPubKey","threshold":2,"public_keys":[` +
+		bank + `,{"@type":"/cosmos.crypto.secp256k1.PubKey","key":"` + memberKey + `"}]}`
+
+	single := models.PendingTx{From: bankAddr, UnsignedTx: unsignedTx}
+	multi := models.PendingTx{From: "pokt1multisig", Threshold: 2, Signers: []string{member, bankAddr}, UnsignedTx: unsignedTx}
+
+	tests := []struct {
+		name    string
+		tx      models.PendingTx
+		signed  string
+		wantErr bool
+	}{
+		{
+			name:    "matching body and fee signed by the bank",
+			tx:      single,
+			signed:  signedTx("1", bank),
+			wantErr: false,
+		},
+		{
+			name:    "no signatures",
+			tx:      single,
+			signed:  unsignedTx,
+			wantErr: true,
+		},
+		{
+			name:    "different amount",
+			tx:      single,
+			signed:  `{"body":{"messages":[{"@type":"/cosmos.bank.v1beta1.MsgSend","amount":[{"denom":"upokt","amount":"999"}]}],"memo":""},"auth_info":{},"signatures":["c2ln"]}`,
+			wantErr: true,
+		},
+		{
+			name:    "tampered fee",
+			tx:      single,
+			signed:  signedTx("5000000", bank),
+			wantErr: true,
+		},
+		{
+			name:    "signed by another key",
+			tx:      single,
+			signed:  signedTx("1", `{"@type":"/cosmos.crypto.secp256k1.PubKey","key":"`+memberKey+`"}`),
+			wantErr: true,
+		},
+		{
+			name:    "no signer public key",
+			tx:      single,
+			signed:  signedTx("1", "null"),
+			wantErr: true,
+		},
+		{
+			name:    "multisig with the configured signers",
+			tx:      multi,
+			signed:  signedTx("1", multisig),
+			wantErr: false,
+		},
+		{
+			name:    "multisig with a different threshold",
+			tx:      models.PendingTx{Threshold: 1, Signers: multi.Signers, UnsignedTx: unsignedTx},
+			signed:  signedTx("1", multisig),
+			wantErr: true,
+		},
+		{
+			name:    "multisig with other members",
+			tx:      models.PendingTx{Threshold: 2, Signers: []string{bankAddr, "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"}, UnsignedTx: unsignedTx},
+			signed:  signedTx("1", multisig),
+			wantErr: true,
+		},
+		{
+			name:    "multisig signed by a single key",
+			tx:      multi,
+			signed:  signedTx("1", bank),
+			wantErr: true,
+		},
+		{
+			name:    "not json",
+			tx:      single,
+			signed:  "signed",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckSigned(tt.tx, []byte(tt.signed))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckSigned() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package pendingtx
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/pokt-network/sam/internal/config"
+	"github.com/pokt-network/sam/internal/models"
+	"github.com/pokt-network/sam/internal/pocket"
+)
+
+const (
+	trackInterval = 15 * time.Second
+	// confirmTimeout is how long a broadcast tx may stay unindexed before it
+	// is marked failed (e.g. dropped from the mempool).
+	confirmTimeout = 10 * time.Minute
+)
+
+// Tracker polls the chain for broadcast pending transactions and records
+// whether they were confirmed or failed.
+type Tracker struct {
+	Store  *Store
+	Config *config.Config
+	Client pocket.ChainReader
+	Logger *slog.Logger
+}
+
+// NewTracker creates a confirmation tracker for pending transactions.
+func NewTracker(store *Store, cfg *config.Config, client pocket.ChainReader, logger *slog.Logger) *Tracker {
+	return &Tracker{
+		Store:  store,
+		Config: cfg,
+		Client: client,
+		Logger: logger,
+	}
+}
+
+// Run starts the tracker loop. It blocks until ctx is cancelled.
+func (t *Tracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(trackInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.RunOnce(ctx)
+		}
+	}
+}
+
+// RunOnce expires unsigned transactions past their TTL and checks every
+// broadcast transaction once.
+func (t *Tracker) RunOnce(ctx context.Context) {
+	expired, err := t.Store.Expire(time.Now())
+	if err != nil {
+		t.Logger.Error("failed to persist expired pending transactions", "error", err)
+	}
+	for _, tx := range expired {
+		t.Logger.Warn("pending tx expired without signatures", "id", tx.ID, "kind", tx.Kind, "to", tx.To)
+	}
+
+	for _, tx := range t.Store.WithStatus(models.PendingBroadcast) {
+		if ctx.Err() != nil {
+			return
+		}
+
+		netCfg, ok := t.Config.Config.Networks[tx.Network]
+		if !ok || tx.TxHash == "" {
+			continue
+		}
+
+		resp, found, err := t.Client.QueryTx(ctx, tx.TxHash, netCfg.APIEndpoint)
+		if err != nil {
+			t.Logger.Warn("pending tx: confirmation query failed", "id", tx.ID, "tx_hash", tx.TxHash, "error", err)
+			continue
+		}
+
+		now := time.Now()
+		switch {
+		case found && resp.TxResponse.Code == 0:
+			t.update(tx.ID, func(p *models.PendingTx) error {
+				p.Status = models.PendingConfirmed
+				p.ConfirmedAt = &now
+				return nil
+			})
+			t.Logger.Info("pending tx confirmed", "id", tx.ID, "tx_hash", tx.TxHash, "height", resp.TxResponse.Height)
+		case found:
+			t.update(tx.ID, func(p *models.PendingTx) error {
+				p.Status = models.PendingFailed
+				p.Error = resp.TxResponse.RawLog
+				return nil
+			})
+			t.Logger.Error("pending tx failed on chain", "id", tx.ID, "tx_hash", tx.TxHash, "code", resp.TxResponse.Code)
+		case tx.BroadcastAt != nil && now.Sub(*tx.BroadcastAt) > confirmTimeout:
+			t.update(tx.ID, func(p *models.PendingTx) error {
+				p.Status = models.PendingFailed
+				p.Error = "transaction was not included in a block"
+				return nil
+			})
+			t.Logger.Error("pending tx not confirmed before timeout", "id", tx.ID, "tx_hash", tx.TxHash)
+		}
+	}
+}
+
+func (t *Tracker) update(id string, fn func(p *models.PendingTx) error) {
+	if _, err := t.Store.Update(id, fn); err != nil {
+		t.Logger.Error("pending tx: failed to update status", "id", id, "error", err)
+	}
+}
@@ -0,0 +1,241 @@
+package pocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/pokt-network/sam/internal/models"
+)
+
+// MsgStakeApplicationType is the type URL the bank is granted via authz so it
+// can (up)stake on behalf of an application.
+const MsgStakeApplicationType = "/pocket.application.MsgStakeApplication"
+
+// QueryStakeGrant returns the granter→grantee authz grant for
+// MsgStakeApplication, or nil if none exists.
+func (c *Client) QueryStakeGrant(ctx context.Context, granter, grantee, apiEndpoint string) (*models.AuthzGrant, error) {
+	q := url.Values{}
+	q.Set("granter", granter)
+	q.Set("grantee", grantee)
+	q.Set("msg_type_url", MsgStakeApplicationType)
+	path := "/cosmos/authz/v1beta1/grants?" + q.Encode()
+	c.Logger.Debug("querying authz grant", "endpoint", apiEndpoint, "path", path)
+
+	resp, err := c.get(ctx, apiEndpoint, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query authz API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// The authz module answers "no grant" with a 404 ("authorization not
+	// found") rather than an empty list.
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+		return nil, fmt.Errorf("authz API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read authz response: %w", err)
+	}
+
+	var grantsResp models.APIGrantsResponse
+	if err := json.Unmarshal(body, &grantsResp); err != nil {
+		return nil, fmt.Errorf("failed to parse authz response: %w", err)
+	}
+
+	for _, g := range grantsResp.Grants {
+		if g.Authorization.Msg != "" && g.Authorization.Msg != MsgStakeApplicationType {
+			continue
+		}
+		return stakeGrant(granter, grantee, g.Expiration), nil
+	}
+
+	return nil, nil
+}
+
+// ListStakeGrants returns the MsgStakeApplication grants held by grantee,
+// keyed by granter, with one paged listing instead of a query per granter.
+func (c *Client) ListStakeGrants(ctx context.Context, grantee, apiEndpoint string) (map[string]*models.AuthzGrant, error) {
+	entries, err := listAll(ctx, c, apiEndpoint, "/cosmos/authz/v1beta1/grants/grantee/"+grantee, nil, "authz grants",
+		func(p *models.APIGrantsResponse) ([]models.APIGrant, models.APIPagination) {
+			return p.Grants, p.Pagination
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	grants := make(map[string]*models.AuthzGrant)
+	for _, g := range entries {
+		// Unlike the single query, the listing isn't filtered by message.
+		if g.Authorization.Msg != MsgStakeApplicationType {
+			continue
+		}
+		grants[g.Granter] = stakeGrant(g.Granter, grantee, g.Expiration)
+	}
+	return grants, nil
+}
+
+func stakeGrant(granter, grantee string, expiration *time.Time) *models.AuthzGrant {
+	return &models.AuthzGrant{
+		Granter:    granter,
+		Grantee:    grantee,
+		MsgTypeURL: MsgStakeApplicationType,
+		Expiration: expiration,
+		Active:     expiration == nil || expiration.After(time.Now()),
+	}
+}
+
+// GrantStakeAuthz lets the bank stake on behalf of an application. The grant
+// is signed by the application key, so it must be in the keyring once; after
+// that only the bank key is needed for upstakes.
+func (e *Executor) GrantStakeAuthz(ctx context.Context, appAddress, bankAddress, network string, expirationDays int, rpcEndpoint string) (*models.TransactionResponse, error) {
+	if expirationDays < 0 {
+		return nil, fmt.Errorf("expiration days must not be negative")
+	}
+
+	e.Logger.Info("granting stake authz", "app", appAddress, "bank", bankAddress, "expiration_days", expirationDays)
+
+	args := []string{
+		"tx", "authz", "grant",
+		bankAddress,
+		"generic",
+		"--msg-type", MsgStakeApplicationType,
+		"--from", appAddress,
+		"--node", rpcEndpoint,
+		"--chain-id", network,
+		"--yes",
+		"--gas=auto",
+		e.feesFlag(network),
+		"--output", "json",
+	}
+
+	if expirationDays > 0 {
+		exp := time.Now().AddDate(0, 0, expirationDays).Unix()
+		args = append(args, "--expiration", strconv.FormatInt(exp, 10))
+	}
+
+	if e.Config.Config.KeyringBackend != "" {
+		args = append(args, "--keyring-backend", e.Config.Config.KeyringBackend)
+	}
+
+	return e.runTx(ctx, "authz grant", appAddress, args)
+}
+
+// RevokeStakeAuthz removes the bank's grant to stake on behalf of an application.
+func (e *Executor) RevokeStakeAuthz(ctx context.Context, appAddress, bankAddress, network, rpcEndpoint string) (*models.TransactionResponse, error) {
+	e.Logger.Info("revoking stake authz", "app", appAddress, "bank", bankAddress)
+
+	args := []string{
+		"tx", "authz", "revoke",
+		bankAddress,
+		MsgStakeApplicationType,
+		"--from", appAddress,
+		"--node", rpcEndpoint,
+		"--chain-id", network,
+		"--yes",
+		"--gas=auto",
+		e.feesFlag(network),
+		"--output", "json",
+	}
+
+	if e.Config.Config.KeyringBackend != "" {
+		args = append(args, "--keyring-backend", e.Config.Config.KeyringBackend)
+	}
+
+	return e.runTx(ctx, "authz revoke", appAddress, args)
+}
+
+// useStakeGrant reports whether an upstake for appAddress should be executed
+// by the bank through authz: the network must have a hot bank and the bank
+// must hold an active grant from the app.
+func (e *Executor) useStakeGrant(ctx context.Context, appAddress, bankAddress, network, apiEndpoint string) bool {
+	netCfg, ok := e.Config.Config.Networks[network]
+	if !ok || bankAddress == "" || netCfg.OfflineBank() {
+		return false
+	}
+
+	grant, err := e.Client.QueryStakeGrant(ctx, appAddress, bankAddress, apiEndpoint)
+	if err != nil {
+		e.Logger.Warn("failed to query stake grant, signing with app key", "address", appAddress, "error", err)
+		return false
+	}
+	return grant != nil && grant.Active
+}
+
+// upstakeViaAuthz generates the stake message for appAddress unsigned and has
+// the bank submit it with tx authz exec.
+func (e *Executor) upstakeViaAuthz(ctx context.Context, stakeConfig, appAddress, bankAddress, network, rpcEndpoint string) (*models.TransactionResponse, error) {
+	genArgs := []string{
+		"tx", "application", "stake-application",
+		"--config", stakeConfig,
+		"--from", appAddress,
+		"--node", rpcEndpoint,
+		"--chain-id", network,
+		"--generate-only",
+		"--output", "json",
+	}
+
+	e.Logger.Debug("generate stake for authz exec", "args", genArgs)
+
+	unsigned, err := e.Run(ctx, genArgs...)
+	if err != nil {
+		e.Logger.Error("generate stake for authz exec failed", "error", err)
+		return &models.TransactionResponse{
+			Success: false,
+			Message: "upstake transaction failed",
+		}, nil
+	}
+	if !json.Valid([]byte(unsigned)) {
+		return nil, fmt.Errorf("pocketd returned a non-JSON stake transaction")
+	}
+
+	tempFile, err := os.CreateTemp("", "pocketd-authz-*.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp tx file: %w", err)
+	}
+	if err := tempFile.Chmod(0600); err != nil {
+		tempFile.Close()
+		os.Remove(tempFile.Name())
+		return nil, fmt.Errorf("failed to set temp file permissions: %w", err)
+	}
+	tempTx := tempFile.Name()
+
+	if _, err := tempFile.WriteString(unsigned); err != nil {
+		tempFile.Close()
+		os.Remove(tempTx)
+		return nil, fmt.Errorf("failed to write temp tx file: %w", err)
+	}
+	if err := tempFile.Close(); err != nil {
+		os.Remove(tempTx)
+		return nil, fmt.Errorf("failed to close temp tx file: %w", err)
+	}
+	defer os.Remove(tempTx)
+
+	args := []string{
+		"tx", "authz", "exec", tempTx,
+		"--from", bankAddress,
+		"--node", rpcEndpoint,
+		"--chain-id", network,
+		"--yes",
+		"--gas=auto",
+		e.feesFlag(network),
+		"--output", "json",
+	}
+
+	if e.Config.Config.KeyringBackend != "" {
+		args = append(args, "--keyring-backend", e.Config.Config.KeyringBackend)
+	}
+
+	return e.runTx(ctx, "authz exec upstake", bankAddress, args)
+}
@@ -0,0 +1,52 @@
+package pocket
+
+import (
+	"context"
+
+	"github.com/pokt-network/sam/internal/models"
+)
+
+// ChainReader is the read side of a Pocket network: balances, applications,
+// services, module params, blocks, grants and transactions. Client
+// implements it over the REST API.
+type ChainReader interface {
+	QueryBalance(ctx context.Context, address, apiEndpoint, denom string) (int64, error)
+	QueryApplication(ctx context.Context, address, apiEndpoint, network, denom string) (*models.Application, error)
+	ListApplications(ctx context.Context, apiEndpoint, network, gateway string) ([]*models.Application, error)
+	QueryAllBalances(ctx context.Context, address, apiEndpoint string) ([]models.Coin, error)
+	QueryBalances(ctx context.Context, addresses []string, apiEndpoint string) (map[string][]models.Coin, error)
+	QueryServices(ctx context.Context, apiEndpoint string) ([]models.ServiceInfo, error)
+	QueryLatestBlock(ctx context.Context, apiEndpoint string) (*models.NodeStatus, error)
+	QueryApplicationParams(ctx context.Context, apiEndpoint string) (*models.ApplicationParams, error)
+	QuerySharedParams(ctx context.Context, apiEndpoint string) (*models.SharedParams, error)
+	QueryTokenomicsParams(ctx context.Context, apiEndpoint string) (*models.TokenomicsParams, error)
+	QueryBankAccount(ctx context.Context, address, apiEndpoint, network, denom string) (*models.BankAccount, error)
+	QueryTx(ctx context.Context, txHash, apiEndpoint string) (*models.APITxResponse, bool, error)
+	QueryAccount(ctx context.Context, address, apiEndpoint string) (uint64, uint64, error)
+	QueryStakeGrant(ctx context.Context, granter, grantee, apiEndpoint string) (*models.AuthzGrant, error)
+	QueryFeeAllowance(ctx context.Context, granter, grantee, apiEndpoint, denom string) (*models.FeeAllowance, error)
+	ListStakeGrants(ctx context.Context, grantee, apiEndpoint string) (map[string]*models.AuthzGrant, error)
+	ListFeeAllowances(ctx context.Context, granter, apiEndpoint, denom string) (map[string]*models.FeeAllowance, error)
+}
+
+// TxSubmitter is the write side of a Pocket network. Executor implements it
+// with pocketd.
+type TxSubmitter interface {
+	StakeNewApplication(ctx context.Context, appAddress, serviceID, network string, amountUpokt int64, rpcEndpoint string) (*models.TransactionResponse, error)
+	UpstakeApplication(ctx context.Context, appAddress, bankAddress, network string, amount int64, rpcEndpoint, apiEndpoint string) (*models.TransactionResponse, error)
+	FundApplication(ctx context.Context, appAddress, bankAddress, network string, amount int64, rpcEndpoint string) (*models.TransactionResponse, error)
+	SweepApplication(ctx context.Context, appAddress, bankAddress, network string, amount int64, rpcEndpoint string) (*models.TransactionResponse, error)
+	GrantStakeAuthz(ctx context.Context, appAddress, bankAddress, network string, expirationDays int, rpcEndpoint string) (*models.TransactionResponse, error)
+	RevokeStakeAuthz(ctx context.Context, appAddress, bankAddress, network, rpcEndpoint string) (*models.TransactionResponse, error)
+	GrantFeeAllowance(ctx context.Context, appAddress, bankAddress, network string, spendLimit int64, expirationDays int, rpcEndpoint string) (*models.TransactionResponse, error)
+	RevokeFeeAllowance(ctx context.Context, appAddress, bankAddress, network, rpcEndpoint string) (*models.TransactionResponse, error)
+	HasFeeGrant(ctx context.Context, appAddress, network string) bool
+	BroadcastSigned(ctx context.Context, signedTx []byte, network, rpcEndpoint string) (*models.TransactionResponse, error)
+	Multisign(ctx context.Context, tx models.PendingTx, keyName, rpcEndpoint string) ([]byte, error)
+	KeyringStatus() *KeyringStatus
+}
+
+var (
+	_ ChainReader = (*Client)(nil)
+	_ TxSubmitter = (*Executor)(nil)
+)
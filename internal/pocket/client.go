@@ -1,11 +1,13 @@
 package pocket
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"sort"
 	"strconv"
 	"time"
 
@@ -15,7 +17,13 @@ import (
 // Client performs read-only queries against the Pocket Network REST API.
 type Client struct {
 	HTTP   *http.Client
+	Retry  RetryPolicy
 	Logger *slog.Logger
+
+	// Endpoints supplies fallback API endpoints, records their health and
+	// holds their circuit breakers. Endpoints that aren't configured are
+	// tracked on first use.
+	Endpoints *Endpoints
 }
 
 // maxResponseBody is the maximum size of an API response body (1 MB).
@@ -37,55 +45,139 @@ func NewClient(logger *slog.Logger) *Client {
 				return nil
 			},
 		},
-		Logger: logger,
+		Retry:     DefaultRetryPolicy,
+		Logger:    logger,
+		Endpoints: newEndpoints(),
 	}
 }
 
-// QueryBalance returns the uPOKT balance for an address.
-func (c *Client) QueryBalance(address, apiEndpoint string) (int64, error) {
-	url := fmt.Sprintf("%s/cosmos/bank/v1beta1/balances/%s", apiEndpoint, address)
-	c.Logger.Debug("querying balance", "url", url)
+// get issues a GET for path bound to ctx, so a cancelled request or shutdown
+// aborts the query. It starts at the healthiest endpoint of apiEndpoint's
+// network and fails over to the next on a retryable error, skipping
+// endpoints whose circuit is open. When every endpoint has failed it backs
+// off and retries, up to Retry.Attempts times. A non-retryable response, or
+// the very last one, is returned as is. Every request first waits for the
+// network's concurrency and the endpoint's rate limits.
+func (c *Client) get(ctx context.Context, apiEndpoint, path string) (*http.Response, error) {
+	attempts := max(c.Retry.Attempts, 1)
+
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			if err := sleep(ctx, c.Retry.backoff(attempt-1)); err != nil {
+				return nil, fmt.Errorf("query aborted: %w", err)
+			}
+		}
 
-	resp, err := c.HTTP.Get(url)
-	if err != nil {
-		return 0, fmt.Errorf("failed to query balance API: %w", err)
-	}
-	defer resp.Body.Close()
+		group, candidates := c.Endpoints.candidates(EndpointAPI, apiEndpoint)
+		tried := 0
+		for i, ep := range candidates {
+			if !c.Endpoints.allow(ep) {
+				continue
+			}
+			tried++
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
-		return 0, fmt.Errorf("balance API returned status %d: %s", resp.StatusCode, string(body))
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep.url+path, nil)
+			if err != nil {
+				c.Endpoints.endTrial(ep)
+				return nil, err
+			}
+
+			// Wait for a request slot on the network and a token for the
+			// endpoint, so fan-outs stay under the endpoint's rate limits.
+			release, err := group.acquire(ctx)
+			if err != nil {
+				c.Endpoints.endTrial(ep)
+				return nil, fmt.Errorf("query aborted: %w", err)
+			}
+			if err := ep.limiter.Wait(ctx); err != nil {
+				release()
+				c.Endpoints.endTrial(ep)
+				return nil, fmt.Errorf("query aborted: %w", err)
+			}
+
+			start := time.Now()
+			resp, err := c.HTTP.Do(req)
+			release()
+			if ctx.Err() != nil {
+				if err == nil {
+					resp.Body.Close()
+				}
+				c.Endpoints.endTrial(ep)
+				return nil, fmt.Errorf("query aborted: %w", ctx.Err())
+			}
+
+			if err != nil {
+				c.Endpoints.record(ep, time.Since(start), err)
+				if !retryableError(err) {
+					return nil, err
+				}
+			} else {
+				if !retryableStatus(resp.StatusCode) {
+					c.Endpoints.record(ep, time.Since(start), nil)
+					return resp, nil
+				}
+				err = fmt.Errorf("returned status %d", resp.StatusCode)
+				c.Endpoints.record(ep, time.Since(start), err)
+				if attempt == attempts && i == len(candidates)-1 {
+					return resp, nil
+				}
+				resp.Body.Close()
+			}
+
+			lastErr = err
+			c.Logger.Warn("API request failed", "endpoint", ep.url, "path", path, "attempt", attempt, "error", err)
+		}
+
+		if tried == 0 {
+			if lastErr != nil {
+				return nil, lastErr
+			}
+			return nil, fmt.Errorf("%w: %s", ErrCircuitOpen, apiEndpoint)
+		}
 	}
+	return nil, lastErr
+}
 
-	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+// QueryBalance returns an address's balance in denom, its network's base
+// denom.
+func (c *Client) QueryBalance(ctx context.Context, address, apiEndpoint, denom string) (int64, error) {
+	coins, err := c.QueryAllBalances(ctx, address, apiEndpoint)
 	if err != nil {
-		return 0, fmt.Errorf("failed to read balance response: %w", err)
+		return 0, err
 	}
+	return balanceOf(coins, denom)
+}
 
-	var balanceResp models.APIBalanceResponse
-	if err := json.Unmarshal(body, &balanceResp); err != nil {
-		return 0, fmt.Errorf("failed to parse balance response: %w", err)
-	}
+// QueryAllBalances returns every coin held by an address, across all pages.
+func (c *Client) QueryAllBalances(ctx context.Context, address, apiEndpoint string) ([]models.Coin, error) {
+	return listAll(ctx, c, apiEndpoint, "/cosmos/bank/v1beta1/balances/"+address, nil, "balance",
+		func(p *models.APIBalanceResponse) ([]models.Coin, models.APIPagination) {
+			return p.Balances, p.Pagination
+		})
+}
 
-	for _, coin := range balanceResp.Balances {
-		if coin.Denom == "upokt" {
-			balance, err := strconv.ParseInt(coin.Amount, 10, 64)
+// balanceOf returns the amount of denom among coins; 0 when it isn't held.
+func balanceOf(coins []models.Coin, denom string) (int64, error) {
+	for _, coin := range coins {
+		if coin.Denom == denom {
+			balance, err := coin.Upokt()
 			if err != nil {
 				return 0, fmt.Errorf("failed to parse balance amount: %w", err)
 			}
 			return balance, nil
 		}
 	}
-
 	return 0, nil
 }
 
-// QueryApplication fetches application details and its liquid balance.
-func (c *Client) QueryApplication(address, apiEndpoint, network string) (*models.Application, error) {
-	url := fmt.Sprintf("%s/pokt-network/poktroll/application/application/%s", apiEndpoint, address)
-	c.Logger.Debug("querying application", "url", url)
+// QueryApplication fetches application details and its liquid balance in
+// denom, the network's base denom.
+func (c *Client) QueryApplication(ctx context.Context, address, apiEndpoint, network, denom string) (*models.Application, error) {
+	path := fmt.Sprintf("/pokt-network/poktroll/application/application/%s", address)
+	c.Logger.Debug("querying application", "endpoint", apiEndpoint, "path", path)
 
-	resp, err := c.HTTP.Get(url)
+	resp, err := c.get(ctx, apiEndpoint, path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query API: %w", err)
 	}
@@ -109,72 +201,116 @@ func (c *Client) QueryApplication(address, apiEndpoint, network string) (*models
 		return nil, fmt.Errorf("failed to parse API response: %w", err)
 	}
 
+	apiResp.Application.Address = address
+	app, err := c.applicationFromAPI(apiResp.Application, network)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without its balance the app would look empty and be funded again.
+	coins, err := c.QueryAllBalances(ctx, address, apiEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query balance: %w", err)
+	}
+	app.Denom = denom
+	app.Balances = coins
+	if app.LiquidBalance, err = balanceOf(coins, app.Denom); err != nil {
+		return nil, fmt.Errorf("failed to parse balance: %w", err)
+	}
+
+	return app, nil
+}
+
+// applicationFromAPI converts a poktroll application, without its liquid
+// balance, which comes from the bank module. A stake that can't be parsed
+// (or overflows int64) is an error rather than a zero stake.
+func (c *Client) applicationFromAPI(entry models.APIApplication, network string) (*models.Application, error) {
 	app := &models.Application{
-		Address: address,
-		Network: network,
+		Address:    entry.Address,
+		Network:    network,
+		ServiceIDs: make([]string, 0, len(entry.ServiceConfigs)),
+		Gateways:   append([]string{}, entry.DelegateeGatewayAddresses...),
 	}
 
-	if apiResp.Application.Stake != nil {
-		stakeAmount, err := strconv.ParseInt(apiResp.Application.Stake.Amount, 10, 64)
+	if entry.Stake != nil {
+		stakeAmount, err := entry.Stake.Upokt()
 		if err != nil {
-			c.Logger.Warn("failed to parse stake amount", "address", address, "error", err)
-		} else {
-			app.Stake = stakeAmount
-			c.Logger.Debug("application stake", "address", address, "stake_upokt", stakeAmount)
+			return nil, fmt.Errorf("failed to parse stake: %w", err)
 		}
+		app.Stake = stakeAmount
+		c.Logger.Debug("application stake", "address", entry.Address, "stake_upokt", stakeAmount)
 	}
 
-	if len(apiResp.Application.ServiceConfigs) > 0 {
-		sc := apiResp.Application.ServiceConfigs[0]
+	for _, sc := range entry.ServiceConfigs {
 		if sc.Service != nil {
-			app.ServiceID = sc.Service.ID
+			app.ServiceIDs = append(app.ServiceIDs, sc.Service.ID)
 		} else {
-			app.ServiceID = sc.ServiceID
+			app.ServiceIDs = append(app.ServiceIDs, sc.ServiceID)
+		}
+	}
+	if len(app.ServiceIDs) > 0 {
+		app.ServiceID = app.ServiceIDs[0]
+	}
+	if len(app.Gateways) > 0 {
+		app.Gateway = app.Gateways[0]
+	}
+
+	for key, list := range entry.PendingUndelegations {
+		height, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			c.Logger.Warn("failed to parse undelegation height", "address", entry.Address, "height", key, "error", err)
+			continue
 		}
+		app.PendingUndelegations = append(app.PendingUndelegations, models.PendingUndelegation{
+			SessionEndHeight: height,
+			Gateways:         list.GatewayAddresses,
+		})
 	}
+	sort.Slice(app.PendingUndelegations, func(i, j int) bool {
+		return app.PendingUndelegations[i].SessionEndHeight < app.PendingUndelegations[j].SessionEndHeight
+	})
 
-	if len(apiResp.Application.DelegateeGatewayAddresses) > 0 {
-		app.Gateway = apiResp.Application.DelegateeGatewayAddresses[0]
+	if entry.PendingTransfer != nil {
+		height, err := parseHeight(entry.PendingTransfer.SessionEndHeight)
+		if err != nil {
+			c.Logger.Warn("failed to parse pending transfer height", "address", entry.Address, "error", err)
+		}
+		app.PendingTransfer = &models.PendingTransfer{
+			DestinationAddress: entry.PendingTransfer.DestinationAddress,
+			SessionEndHeight:   height,
+		}
 	}
 
-	balance, err := c.QueryBalance(address, apiEndpoint)
+	height, err := parseHeight(entry.UnstakeSessionEndHeight)
 	if err != nil {
-		c.Logger.Warn("failed to query balance", "address", address, "error", err)
-	} else {
-		app.LiquidBalance = balance
+		c.Logger.Warn("failed to parse unstake session end height", "address", entry.Address, "error", err)
 	}
+	app.UnstakeSessionEndHeight = height
 
 	return app, nil
 }
 
-// QueryServices returns available services on the network.
-func (c *Client) QueryServices(apiEndpoint string) ([]models.ServiceInfo, error) {
-	url := fmt.Sprintf("%s/pokt-network/poktroll/service/service", apiEndpoint)
-	c.Logger.Debug("querying services", "url", url)
-
-	resp, err := c.HTTP.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query services API: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
-		return nil, fmt.Errorf("services API returned status %d: %s", resp.StatusCode, string(body))
+// parseHeight parses a uint64 block height encoded as a string; an empty
+// string is 0.
+func parseHeight(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
 	}
+	return strconv.ParseInt(s, 10, 64)
+}
 
-	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+// QueryServices returns every service on the network, across all pages.
+func (c *Client) QueryServices(ctx context.Context, apiEndpoint string) ([]models.ServiceInfo, error) {
+	entries, err := listAll(ctx, c, apiEndpoint, "/pokt-network/poktroll/service/service", nil, "services",
+		func(p *models.APIServicesResponse) ([]models.APIServiceEntry, models.APIPagination) {
+			return p.Service, p.Pagination
+		})
 	if err != nil {
-		return nil, fmt.Errorf("failed to read services response: %w", err)
-	}
-
-	var apiResp models.APIServicesResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to parse services response: %w", err)
+		return nil, err
 	}
 
-	services := make([]models.ServiceInfo, 0, len(apiResp.Service))
-	for _, s := range apiResp.Service {
+	services := make([]models.ServiceInfo, 0, len(entries))
+	for _, s := range entries {
 		services = append(services, models.ServiceInfo{
 			ID:   s.ID,
 			Name: s.Name,
@@ -184,16 +320,56 @@ func (c *Client) QueryServices(apiEndpoint string) ([]models.ServiceInfo, error)
 	return services, nil
 }
 
-// QueryBankAccount returns the bank account balance for a network.
-func (c *Client) QueryBankAccount(address, apiEndpoint, network string) (*models.BankAccount, error) {
-	balance, err := c.QueryBalance(address, apiEndpoint)
+// QueryBankAccount returns the bank account balance for a network, in its
+// base denom denom.
+func (c *Client) QueryBankAccount(ctx context.Context, address, apiEndpoint, network, denom string) (*models.BankAccount, error) {
+	coins, err := c.QueryAllBalances(ctx, address, apiEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query bank balance: %w", err)
+	}
+	balance, err := balanceOf(coins, denom)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query bank balance: %w", err)
 	}
 
 	return &models.BankAccount{
-		Address: address,
-		Balance: balance,
-		Network: network,
+		Address:  address,
+		Balance:  balance,
+		Denom:    denom,
+		Balances: append([]models.Coin{}, coins...),
+		Network:  network,
 	}, nil
 }
+
+// QueryTx looks up a transaction by hash. It returns found=false when the
+// transaction is not (yet) indexed by the node.
+func (c *Client) QueryTx(ctx context.Context, txHash, apiEndpoint string) (*models.APITxResponse, bool, error) {
+	path := fmt.Sprintf("/cosmos/tx/v1beta1/txs/%s", txHash)
+	c.Logger.Debug("querying tx", "endpoint", apiEndpoint, "path", path)
+
+	resp, err := c.get(ctx, apiEndpoint, path)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to query tx API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+		return nil, false, fmt.Errorf("tx API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read tx response: %w", err)
+	}
+
+	var txResp models.APITxResponse
+	if err := json.Unmarshal(body, &txResp); err != nil {
+		return nil, false, fmt.Errorf("failed to parse tx response: %w", err)
+	}
+
+	return &txResp, true, nil
+}
@@ -0,0 +1,142 @@
+package pocket
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pokt-network/sam/internal/config"
+	"github.com/pokt-network/sam/internal/models"
+)
+
+func TestClient_QueryCancelled(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	client := NewClient(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	_, err := client.QueryBalance(ctx, "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", srv.URL, models.Denom)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("QueryBalance() error = %v, want context canceled", err)
+	}
+}
+
+func TestClient_QueryApplication_FullModel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/cosmos/bank/") {
+			io.WriteString(w, `{"balances":[{"denom":"upokt","amount":"42"}]}`)
+			return
+		}
+		io.WriteString(w, `{"application":{
+			"address":"pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			"stake":{"denom":"upokt","amount":"1000"},
+			"service_configs":[{"service_id":"anvil"},{"service_id":"eth"}],
+			"delegatee_gateway_addresses":["pokt1gw1","pokt1gw2"],
+			"pending_undelegations":{"300":{"gateway_addresses":["pokt1gw4"]},"120":{"gateway_addresses":["pokt1gw3"]}},
+			"unstake_session_end_height":"500",
+			"pending_transfer":{"destination_address":"pokt1dest","session_end_height":"450"}
+		}}`)
+	}))
+	defer srv.Close()
+	client := NewClient(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	app, err := client.QueryApplication(context.Background(), "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", srv.URL, "pocket", models.Denom)
+	if err != nil {
+		t.Fatalf("QueryApplication() error = %v", err)
+	}
+
+	if app.ServiceID != "anvil" || !slices.Equal(app.ServiceIDs, []string{"anvil", "eth"}) {
+		t.Errorf("services = %q %v", app.ServiceID, app.ServiceIDs)
+	}
+	if app.Gateway != "pokt1gw1" || !slices.Equal(app.Gateways, []string{"pokt1gw1", "pokt1gw2"}) {
+		t.Errorf("gateways = %q %v", app.Gateway, app.Gateways)
+	}
+	if len(app.PendingUndelegations) != 2 || app.PendingUndelegations[0].SessionEndHeight != 120 ||
+		app.PendingUndelegations[0].Gateways[0] != "pokt1gw3" || app.PendingUndelegations[1].SessionEndHeight != 300 {
+		t.Errorf("pending undelegations = %+v, want heights 120 and 300 in order", app.PendingUndelegations)
+	}
+	if app.PendingTransfer == nil || app.PendingTransfer.DestinationAddress != "pokt1dest" || app.PendingTransfer.SessionEndHeight != 450 {
+		t.Errorf("pending transfer = %+v", app.PendingTransfer)
+	}
+	if app.UnstakeSessionEndHeight != 500 || app.Stake != 1000 || app.LiquidBalance != 42 {
+		t.Errorf("app = %+v", app)
+	}
+}
+
+func TestClient_QueryApplication_StakeOverflow(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/cosmos/bank/") {
+			io.WriteString(w, `{"balances":[]}`)
+			return
+		}
+		io.WriteString(w, `{"application":{"stake":{"denom":"upokt","amount":"9223372036854775808"}}}`)
+	}))
+	defer srv.Close()
+	client := NewClient(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	// A stake beyond int64 is an error, not a zero stake to top up.
+	if app, err := client.QueryApplication(context.Background(), "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", srv.URL, "pocket", models.Denom); err == nil {
+		t.Fatalf("QueryApplication() = %+v, want a stake parse error", app)
+	}
+}
+
+func TestQueryChainParams(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pokt-network/poktroll/application/params":
+			io.WriteString(w, `{"params":{"max_delegated_gateways":"7","min_stake":{"denom":"upokt","amount":"1000000"}}}`)
+		case "/pokt-network/poktroll/shared/params":
+			io.WriteString(w, `{"params":{"num_blocks_per_session":"60","grace_period_end_offset_blocks":"1",
+				"claim_window_open_offset_blocks":"1","claim_window_close_offset_blocks":"4",
+				"proof_window_open_offset_blocks":"0","proof_window_close_offset_blocks":"4",
+				"supplier_unbonding_period_sessions":"1","application_unbonding_period_sessions":"1",
+				"compute_units_to_tokens_multiplier":"42"}}`)
+		case "/pokt-network/poktroll/tokenomics/params":
+			io.WriteString(w, `{"params":{"mint_allocation_percentages":{"dao":0.1,"proposer":0.05,"supplier":0.7,"source_owner":0.15,"application":0},
+				"dao_reward_address":"pokt1dao","global_inflation_per_claim":0.1}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	client := newEndpointsClient([]string{srv.URL}, []string{srv.URL})
+
+	params, err := QueryChainParams(context.Background(), client, "pocket", config.NetworkConfig{APIEndpoint: srv.URL})
+	if err != nil {
+		t.Fatalf("QueryChainParams() error = %v", err)
+	}
+	if params.Network != "pocket" || params.Application.MinStake != 1_000_000 || params.Application.MaxDelegatedGateways != 7 {
+		t.Errorf("application params = %+v", params.Application)
+	}
+	if s := params.Shared; s.NumBlocksPerSession != 60 || s.ClaimWindowCloseOffsetBlocks != 4 || s.ComputeUnitsToTokensMultiplier != 42 {
+		t.Errorf("shared params = %+v", s)
+	}
+	if tk := params.Tokenomics; tk.MintAllocationPercentages["supplier"] != 0.7 || tk.DaoRewardAddress != "pokt1dao" || tk.GlobalInflationPerClaim != 0.1 {
+		t.Errorf("tokenomics params = %+v", tk)
+	}
+
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"params":{"min_stake":{"denom":"upokt","amount":"lots"}}}`)
+	}))
+	defer broken.Close()
+	if _, err := client.QueryApplicationParams(context.Background(), broken.URL); err == nil || !strings.Contains(err.Error(), "min_stake") {
+		t.Errorf("QueryApplicationParams() error = %v, want min_stake parse error", err)
+	}
+}
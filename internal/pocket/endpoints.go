@@ -0,0 +1,597 @@
+package pocket
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pokt-network/sam/internal/config"
+	"github.com/pokt-network/sam/internal/models"
+)
+
+// Endpoint kinds.
+const (
+	EndpointAPI = "api" // REST, used for queries
+	EndpointRPC = "rpc" // CometBFT RPC, passed to pocketd as --node
+)
+
+const (
+	// DefaultProbeInterval is how often endpoint heights are probed.
+	DefaultProbeInterval = 30 * time.Second
+
+	// MaxHeightLag is how many blocks an endpoint may trail the highest
+	// endpoint of its network before it is considered unhealthy.
+	MaxHeightLag = 10
+
+	// DefaultStallTimeout is how long an endpoint's height may stay the
+	// same before it is considered unhealthy.
+	DefaultStallTimeout = 5 * time.Minute
+
+	// endpointWindow is how many recent requests the error rate covers.
+	endpointWindow = 20
+
+	// An endpoint is unhealthy after maxConsecutiveFailures failures in a
+	// row, or when more than maxErrorRate of a full window failed.
+	maxConsecutiveFailures = 3
+	maxErrorRate           = 0.5
+)
+
+// Circuit breaker states.
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half-open" // cooldown over; the next request is a trial
+)
+
+// EndpointStatus is the observed health of one endpoint.
+type EndpointStatus struct {
+	URL        string     `json:"url"`
+	Healthy    bool       `json:"healthy"`
+	Active     bool       `json:"active"` // the endpoint currently preferred
+	LatencyMs  int64      `json:"latency_ms"`
+	ErrorRate  float64    `json:"error_rate"`
+	Requests   int        `json:"requests"` // requests in the error-rate window
+	Height     int64      `json:"height,omitempty"`
+	HeightLag  int64      `json:"height_lag"`
+	Stalled    bool       `json:"stalled"`     // height unchanged for longer than the stall timeout
+	CatchingUp bool       `json:"catching_up"` // only reported by RPC endpoints
+	BlockTime  *time.Time `json:"block_time,omitempty"`
+	Circuit    string     `json:"circuit"`
+	LastError  string     `json:"last_error,omitempty"`
+	CheckedAt  *time.Time `json:"checked_at,omitempty"`
+}
+
+// NetworkEndpoints is the endpoint status of one network.
+type NetworkEndpoints struct {
+	Network string           `json:"network"`
+	API     []EndpointStatus `json:"api"`
+	RPC     []EndpointStatus `json:"rpc"`
+}
+
+// endpoint tracks one URL. Fields are guarded by Endpoints.mu.
+type endpoint struct {
+	url         string
+	latency     time.Duration // moving average
+	results     [endpointWindow]bool
+	count, next int       // results recorded (up to the window) and ring position
+	consecutive int       // failures in a row
+	openUntil   time.Time // circuit open until; zero when closed
+	trial       bool      // a half-open trial request is in flight
+	probeFails  int       // failed height probes in a row; never touches the circuit
+	height      int64
+	heightSince time.Time // when height last changed
+	catchingUp  bool
+	blockTime   time.Time
+	lastErr     string
+	checkedAt   time.Time
+	limiter     *tokenBucket // nil: unlimited
+}
+
+func (p *endpoint) errorRate() float64 {
+	if p.count == 0 {
+		return 0
+	}
+	failed := 0
+	for i := 0; i < p.count; i++ {
+		if !p.results[i] {
+			failed++
+		}
+	}
+	return float64(failed) / float64(p.count)
+}
+
+// stalled reports whether p's height hasn't changed for longer than
+// stallTimeout; 0 disables the check.
+func (p *endpoint) stalled(stallTimeout time.Duration) bool {
+	return stallTimeout > 0 && !p.heightSince.IsZero() && time.Since(p.heightSince) > stallTimeout
+}
+
+func (p *endpoint) healthy(best int64, stallTimeout time.Duration) bool {
+	if p.consecutive >= maxConsecutiveFailures || p.probeFails >= maxConsecutiveFailures {
+		return false
+	}
+	if p.count == endpointWindow && p.errorRate() > maxErrorRate {
+		return false
+	}
+	if p.stalled(stallTimeout) {
+		return false
+	}
+	return p.height == 0 || best-p.height <= MaxHeightLag
+}
+
+// endpointGroup is the ordered endpoint list of one network and kind.
+type endpointGroup struct {
+	kind      string
+	endpoints []*endpoint
+	slots     chan struct{} // requests in flight; nil: unlimited
+}
+
+// acquire takes a request slot, waiting until one is free or ctx is done,
+// and returns the func that releases it. A nil group is unlimited.
+func (g *endpointGroup) acquire(ctx context.Context) (release func(), err error) {
+	if g == nil || g.slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case g.slots <- struct{}{}:
+		return func() { <-g.slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (g *endpointGroup) bestHeight() int64 {
+	var best int64
+	for _, p := range g.endpoints {
+		best = max(best, p.height)
+	}
+	return best
+}
+
+// ordered returns the healthy endpoints in configured order, followed by the
+// unhealthy ones as a last resort.
+func (g *endpointGroup) ordered(stallTimeout time.Duration) []*endpoint {
+	best := g.bestHeight()
+	list := make([]*endpoint, 0, len(g.endpoints))
+	var unhealthy []*endpoint
+	for _, p := range g.endpoints {
+		if p.healthy(best, stallTimeout) {
+			list = append(list, p)
+		} else {
+			unhealthy = append(unhealthy, p)
+		}
+	}
+	return append(list, unhealthy...)
+}
+
+// Endpoints tracks the health of every configured API and RPC endpoint,
+// picks which one to use, and runs a circuit breaker per endpoint. Groups
+// are looked up by a network's primary endpoint, which is what callers pass
+// around.
+type Endpoints struct {
+	// BreakerFailures consecutive failures open an endpoint's circuit for
+	// BreakerCooldown; requests then skip it until the cooldown ends.
+	BreakerFailures int
+	BreakerCooldown time.Duration
+
+	// StallTimeout is how long an endpoint's height may stay the same
+	// before it is unhealthy; 0 disables the check.
+	StallTimeout time.Duration
+
+	mu       sync.Mutex
+	groups   map[string]*endpointGroup // kind + " " + primary URL
+	networks map[string][2]string      // network -> primary API and RPC URL
+}
+
+func newEndpoints() *Endpoints {
+	return &Endpoints{
+		BreakerFailures: DefaultBreakerFailures,
+		BreakerCooldown: DefaultBreakerCooldown,
+		StallTimeout:    DefaultStallTimeout,
+		groups:          make(map[string]*endpointGroup),
+		networks:        make(map[string][2]string),
+	}
+}
+
+// NewEndpoints registers the endpoints of every configured network.
+func NewEndpoints(cfg *config.Config) *Endpoints {
+	e := newEndpoints()
+	if cb := cfg.Config.CircuitBreaker; cb.Failures > 0 {
+		e.BreakerFailures = cb.Failures
+	}
+	if cb := cfg.Config.CircuitBreaker; cb.Cooldown > 0 {
+		e.BreakerCooldown = cb.Cooldown
+	}
+	if cfg.Config.HeightStallTimeout > 0 {
+		e.StallTimeout = cfg.Config.HeightStallTimeout
+	}
+	for name, netCfg := range cfg.Config.Networks {
+		e.networks[name] = [2]string{netCfg.APIEndpoint, netCfg.RPCEndpoint}
+		limits := LimitsFor(netCfg)
+		e.register(EndpointAPI, netCfg.APIEndpointList(), &limits)
+		e.register(EndpointRPC, netCfg.RPCEndpointList(), nil)
+	}
+	return e
+}
+
+// register adds the endpoint group for urls, the first being the primary.
+// With limits, each endpoint gets a token bucket and the group a cap on
+// requests in flight; RPC endpoints are only used by pocketd and have none.
+func (e *Endpoints) register(kind string, urls []string, limits *Limits) {
+	if len(urls) == 0 {
+		return
+	}
+	g := &endpointGroup{kind: kind}
+	if limits != nil {
+		g.slots = make(chan struct{}, max(limits.Concurrency, 1))
+	}
+	for _, u := range urls {
+		p := &endpoint{url: u}
+		if limits != nil {
+			p.limiter = newTokenBucket(limits.RequestsPerSecond, limits.Burst)
+		}
+		g.endpoints = append(g.endpoints, p)
+	}
+	e.groups[kind+" "+urls[0]] = g
+}
+
+// candidates returns the endpoints to try for primary, best first. An
+// unregistered primary is tracked on its own from then on; with a nil
+// Endpoints it is returned untracked.
+func (e *Endpoints) candidates(kind, primary string) (*endpointGroup, []*endpoint) {
+	if e == nil {
+		return nil, []*endpoint{{url: primary}}
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	g, ok := e.groups[kind+" "+primary]
+	if !ok {
+		e.register(kind, []string{primary}, nil)
+		g = e.groups[kind+" "+primary]
+	}
+	return g, g.ordered(e.StallTimeout)
+}
+
+// concurrency returns the cap on requests in flight to primary's network,
+// or DefaultConcurrency when it has none.
+func (e *Endpoints) concurrency(primary string) int {
+	g, _ := e.candidates(EndpointAPI, primary)
+	if g == nil || g.slots == nil {
+		return DefaultConcurrency
+	}
+	return cap(g.slots)
+}
+
+// allow reports whether p's circuit lets a request through: it is closed,
+// or its cooldown has ended and no other trial is in flight, in which case
+// the request becomes the trial. The caller must end a trial with record,
+// or with endTrial if the request was never sent.
+func (e *Endpoints) allow(p *endpoint) bool {
+	if e == nil {
+		return true
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	switch e.circuit(p) {
+	case CircuitOpen:
+		return false
+	case CircuitHalfOpen:
+		if p.trial {
+			return false
+		}
+		p.trial = true
+	}
+	return true
+}
+
+// endTrial lets another trial through p after a request allowed by allow
+// was abandoned before it could be recorded.
+func (e *Endpoints) endTrial(p *endpoint) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	p.trial = false
+	e.mu.Unlock()
+}
+
+// circuit returns p's breaker state. It must be called with e.mu held.
+func (e *Endpoints) circuit(p *endpoint) string {
+	switch {
+	case p.openUntil.IsZero():
+		return CircuitClosed
+	case time.Now().Before(p.openUntil):
+		return CircuitOpen
+	default:
+		return CircuitHalfOpen
+	}
+}
+
+// Pick returns the endpoint to use in place of primary.
+func (e *Endpoints) Pick(kind, primary string) string {
+	_, candidates := e.candidates(kind, primary)
+	return candidates[0].url
+}
+
+// record stores the outcome of a request to p.
+func (e *Endpoints) record(p *endpoint, latency time.Duration, err error) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	p.trial = false
+	p.results[p.next] = err == nil
+	p.next = (p.next + 1) % endpointWindow
+	p.count = min(p.count+1, endpointWindow)
+	p.checkedAt = time.Now()
+
+	if err != nil {
+		p.consecutive++
+		p.lastErr = err.Error()
+		// A failed trial re-opens the circuit straight away.
+		if p.consecutive >= e.BreakerFailures {
+			p.openUntil = time.Now().Add(e.BreakerCooldown)
+		}
+		return
+	}
+	p.consecutive = 0
+	p.openUntil = time.Time{}
+	p.lastErr = ""
+	p.observeLatency(latency)
+}
+
+// recordProbe stores the outcome of a height probe of p. Probes feed health
+// and latency only: the circuit breaker and its half-open trial are driven
+// by real requests through allow and record, so a probe can neither close a
+// circuit nor end a trial in flight.
+func (e *Endpoints) recordProbe(p *endpoint, latency time.Duration, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	p.checkedAt = time.Now()
+	if err != nil {
+		p.probeFails++
+		p.lastErr = err.Error()
+		return
+	}
+	p.probeFails = 0
+	if p.consecutive == 0 {
+		p.lastErr = ""
+	}
+	p.observeLatency(latency)
+}
+
+// observeLatency folds a successful request's latency into p's moving
+// average. It must be called with Endpoints.mu held.
+func (p *endpoint) observeLatency(latency time.Duration) {
+	if p.latency == 0 {
+		p.latency = latency
+	} else {
+		p.latency = (p.latency*4 + latency) / 5
+	}
+}
+
+// recordStatus stores the latest block reported by p.
+func (e *Endpoints) recordStatus(p *endpoint, status *models.NodeStatus) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if status.Height != p.height || p.heightSince.IsZero() {
+		p.heightSince = time.Now()
+	}
+	p.height = status.Height
+	p.catchingUp = status.CatchingUp
+	p.blockTime = status.BlockTime
+}
+
+// Status returns the endpoint status of a network.
+func (e *Endpoints) Status(network string) (NetworkEndpoints, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	primaries, ok := e.networks[network]
+	if !ok {
+		return NetworkEndpoints{}, false
+	}
+	return NetworkEndpoints{
+		Network: network,
+		API:     e.groupStatus(EndpointAPI, primaries[0]),
+		RPC:     e.groupStatus(EndpointRPC, primaries[1]),
+	}, true
+}
+
+// ChainStatus returns a network's chain status as reported by its highest
+// endpoint, API or RPC. The network is stalled when no endpoint's height has
+// advanced within StallTimeout.
+func (e *Endpoints) ChainStatus(network string) (models.NetworkStatus, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	primaries, ok := e.networks[network]
+	if !ok {
+		return models.NetworkStatus{}, false
+	}
+
+	status := models.NetworkStatus{Name: network}
+	var best *endpoint
+	stalled := true
+	for i, kind := range []string{EndpointAPI, EndpointRPC} {
+		g, ok := e.groups[kind+" "+primaries[i]]
+		if !ok {
+			continue
+		}
+		for _, p := range g.endpoints {
+			if p.height == 0 {
+				continue
+			}
+			if !p.stalled(e.StallTimeout) {
+				stalled = false
+			}
+			// RPC endpoints come second and win ties: only they report
+			// catching_up.
+			if best == nil || p.height >= best.height {
+				best = p
+			}
+		}
+	}
+	if best == nil {
+		return status, true
+	}
+
+	status.Height = best.height
+	status.CatchingUp = best.catchingUp
+	status.Stalled = stalled
+	if !best.blockTime.IsZero() {
+		blockTime := best.blockTime
+		status.BlockTime = &blockTime
+	}
+	if !best.checkedAt.IsZero() {
+		checkedAt := best.checkedAt
+		status.CheckedAt = &checkedAt
+	}
+	return status, true
+}
+
+// All returns the endpoint status of every network, sorted by name.
+func (e *Endpoints) All() []NetworkEndpoints {
+	e.mu.Lock()
+	names := make([]string, 0, len(e.networks))
+	for name := range e.networks {
+		names = append(names, name)
+	}
+	e.mu.Unlock()
+	sort.Strings(names)
+
+	all := make([]NetworkEndpoints, 0, len(names))
+	for _, name := range names {
+		status, _ := e.Status(name)
+		all = append(all, status)
+	}
+	return all
+}
+
+// Healthy reports whether every network has at least one healthy API and
+// RPC endpoint.
+func (e *Endpoints) Healthy() bool {
+	for _, network := range e.All() {
+		if !anyHealthy(network.API) || !anyHealthy(network.RPC) {
+			return false
+		}
+	}
+	return true
+}
+
+func anyHealthy(list []EndpointStatus) bool {
+	for _, s := range list {
+		if s.Healthy {
+			return true
+		}
+	}
+	return false
+}
+
+// groupStatus must be called with e.mu held.
+func (e *Endpoints) groupStatus(kind, primary string) []EndpointStatus {
+	g, ok := e.groups[kind+" "+primary]
+	if !ok {
+		return []EndpointStatus{}
+	}
+
+	best := g.bestHeight()
+	active := g.ordered(e.StallTimeout)[0]
+	list := make([]EndpointStatus, 0, len(g.endpoints))
+	for _, p := range g.endpoints {
+		s := EndpointStatus{
+			URL:        p.url,
+			Healthy:    p.healthy(best, e.StallTimeout),
+			Active:     p == active,
+			LatencyMs:  p.latency.Milliseconds(),
+			ErrorRate:  p.errorRate(),
+			Requests:   p.count,
+			Height:     p.height,
+			Stalled:    p.stalled(e.StallTimeout),
+			CatchingUp: p.catchingUp,
+			Circuit:    e.circuit(p),
+			LastError:  p.lastErr,
+		}
+		if p.height > 0 {
+			s.HeightLag = best - p.height
+		}
+		if !p.blockTime.IsZero() {
+			blockTime := p.blockTime
+			s.BlockTime = &blockTime
+		}
+		if !p.checkedAt.IsZero() {
+			checkedAt := p.checkedAt
+			s.CheckedAt = &checkedAt
+		}
+		list = append(list, s)
+	}
+	return list
+}
+
+// probeTargets returns every tracked endpoint with its kind.
+func (e *Endpoints) probeTargets() map[*endpoint]string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	targets := make(map[*endpoint]string)
+	for _, g := range e.groups {
+		for _, p := range g.endpoints {
+			targets[p] = g.kind
+		}
+	}
+	return targets
+}
+
+// ProbeEndpoints fetches the latest block from every tracked endpoint,
+// recording latency, failures, height lag and stalls. Probe results mark
+// endpoints healthy or not but leave circuit breakers to real requests.
+func (c *Client) ProbeEndpoints(ctx context.Context) {
+	if c.Endpoints == nil {
+		return
+	}
+
+	var wg sync.WaitGroup
+	for p, kind := range c.Endpoints.probeTargets() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			start := time.Now()
+			status, err := c.nodeStatus(ctx, kind, p.url)
+			if ctx.Err() != nil {
+				return
+			}
+			c.Endpoints.recordProbe(p, time.Since(start), err)
+			if err != nil {
+				c.Logger.Warn("endpoint probe failed", "endpoint", p.url, "kind", kind, "error", err)
+				return
+			}
+			c.Endpoints.recordStatus(p, status)
+		}()
+	}
+	wg.Wait()
+}
+
+// RunEndpointProbes probes endpoints immediately and then every interval
+// until ctx is cancelled.
+func (c *Client) RunEndpointProbes(ctx context.Context, interval time.Duration) {
+	c.ProbeEndpoints(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.ProbeEndpoints(ctx)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package pocket
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pokt-network/sam/internal/config"
+	"github.com/pokt-network/sam/internal/models"
+)
+
+// heightServer answers balance and latest-block queries, or fails every
+// request with status when status is non-zero.
+func heightServer(t *testing.T, height int64, status int) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+		switch r.URL.Path {
+		case "/cosmos/base/tendermint/v1beta1/blocks/latest":
+			fmt.Fprintf(w, `{"block":{"header":{"height":"%d"}}}`, height)
+		case "/status":
+			fmt.Fprintf(w, `{"result":{"sync_info":{"latest_block_height":"%d"}}}`, height)
+		default:
+			fmt.Fprint(w, `{"balances":[{"denom":"upokt","amount":"42"}]}`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newEndpointsClient(api, rpc []string) *Client {
+	cfg := &config.Config{}
+	cfg.Config.Networks = map[string]config.NetworkConfig{
+		"pocket": {
+			APIEndpoint:  api[0],
+			APIEndpoints: api[1:],
+			RPCEndpoint:  rpc[0],
+			RPCEndpoints: rpc[1:],
+		},
+	}
+	client := NewClient(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	client.Endpoints = NewEndpoints(cfg)
+	client.Retry = RetryPolicy{Attempts: 1}
+	return client
+}
+
+func TestClient_FailsOverToHealthyEndpoint(t *testing.T) {
+	down := heightServer(t, 0, http.StatusBadGateway)
+	up := heightServer(t, 100, 0)
+	client := newEndpointsClient([]string{down.URL, up.URL}, []string{up.URL})
+
+	balance, err := client.QueryBalance(context.Background(), "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", down.URL, models.Denom)
+	if err != nil {
+		t.Fatalf("QueryBalance() error = %v", err)
+	}
+	if balance != 42 {
+		t.Errorf("balance = %d, want 42", balance)
+	}
+
+	// After repeated failures the primary drops behind the fallback.
+	for i := 0; i < maxConsecutiveFailures; i++ {
+		client.QueryBalance(context.Background(), "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", down.URL, models.Denom)
+	}
+	if got := client.Endpoints.Pick(EndpointAPI, down.URL); got != up.URL {
+		t.Errorf("Pick() = %s, want fallback %s", got, up.URL)
+	}
+
+	status, _ := client.Endpoints.Status("pocket")
+	if status.API[0].Healthy || !status.API[1].Healthy || !status.API[1].Active {
+		t.Errorf("API status = %+v", status.API)
+	}
+	if !client.Endpoints.Healthy() {
+		t.Error("Healthy() = false with one healthy endpoint per kind")
+	}
+}
+
+func TestClient_AllEndpointsDown(t *testing.T) {
+	a := heightServer(t, 0, http.StatusServiceUnavailable)
+	b := heightServer(t, 0, http.StatusServiceUnavailable)
+	client := newEndpointsClient([]string{a.URL, b.URL}, []string{a.URL})
+
+	if _, err := client.QueryBalance(context.Background(), "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", a.URL, models.Denom); err == nil {
+		t.Error("expected error when every endpoint fails")
+	}
+}
+
+func TestProbeEndpoints_HeightLag(t *testing.T) {
+	behind := heightServer(t, 100, 0)
+	ahead := heightServer(t, 100+MaxHeightLag+1, 0)
+	client := newEndpointsClient([]string{behind.URL, ahead.URL}, []string{behind.URL, ahead.URL})
+
+	client.ProbeEndpoints(context.Background())
+
+	if got := client.Endpoints.Pick(EndpointAPI, behind.URL); got != ahead.URL {
+		t.Errorf("API Pick() = %s, want %s", got, ahead.URL)
+	}
+	if got := client.Endpoints.Pick(EndpointRPC, behind.URL); got != ahead.URL {
+		t.Errorf("RPC Pick() = %s, want %s", got, ahead.URL)
+	}
+
+	status, _ := client.Endpoints.Status("pocket")
+	if lag := status.RPC[0].HeightLag; lag != MaxHeightLag+1 {
+		t.Errorf("RPC height lag = %d, want %d", lag, MaxHeightLag+1)
+	}
+}
+
+func TestProbeEndpoints_Stall(t *testing.T) {
+	srv := heightServer(t, 100, 0)
+	client := newEndpointsClient([]string{srv.URL}, []string{srv.URL})
+	client.Endpoints.StallTimeout = 50 * time.Millisecond
+
+	client.ProbeEndpoints(context.Background())
+	if status, _ := client.Endpoints.ChainStatus("pocket"); status.Height != 100 || status.Stalled {
+		t.Fatalf("ChainStatus() = %+v, want height 100 and not stalled", status)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	client.ProbeEndpoints(context.Background())
+
+	status, _ := client.Endpoints.Status("pocket")
+	if status.API[0].Healthy || !status.API[0].Stalled {
+		t.Errorf("API status = %+v, want stalled and unhealthy", status.API[0])
+	}
+	if client.Endpoints.Healthy() {
+		t.Error("Healthy() = true with every endpoint stalled")
+	}
+	if chain, _ := client.Endpoints.ChainStatus("pocket"); !chain.Stalled {
+		t.Errorf("ChainStatus() = %+v, want stalled", chain)
+	}
+}
+
+func TestClient_QueryNodeStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/status" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"result":{"sync_info":{"latest_block_height":"812","latest_block_time":"2026-01-02T03:04:05Z","catching_up":true}}}`)
+	}))
+	t.Cleanup(srv.Close)
+	client := newEndpointsClient([]string{srv.URL}, []string{srv.URL})
+
+	status, err := client.QueryNodeStatus(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("QueryNodeStatus() error = %v", err)
+	}
+	want := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	if status.Height != 812 || !status.CatchingUp || !status.BlockTime.Equal(want) {
+		t.Errorf("QueryNodeStatus() = %+v", status)
+	}
+}
+
+func TestExecutor_SelectNode(t *testing.T) {
+	e := &Executor{
+		Endpoints: NewEndpoints(&config.Config{}),
+		Logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+	args := []string{"tx", "bank", "send", "--node", "https://rpc.example.com"}
+	if got := e.selectNode(args); got[4] != "https://rpc.example.com" {
+		t.Errorf("unregistered node rewritten to %s", got[4])
+	}
+
+	down := heightServer(t, 0, http.StatusBadGateway)
+	up := heightServer(t, 100, 0)
+	client := newEndpointsClient([]string{up.URL}, []string{down.URL, up.URL})
+	client.ProbeEndpoints(context.Background())
+	for i := 1; i < maxConsecutiveFailures; i++ {
+		client.ProbeEndpoints(context.Background())
+	}
+
+	e.Endpoints = client.Endpoints
+	args = []string{"tx", "bank", "send", "--node", down.URL, "--yes"}
+	got := e.selectNode(args)
+	if got[4] != up.URL {
+		t.Errorf("--node = %s, want fallback %s", got[4], up.URL)
+	}
+	if args[4] != down.URL {
+		t.Error("selectNode modified its input")
+	}
+}
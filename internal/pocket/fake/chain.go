@@ -0,0 +1,892 @@
+// Package fake provides an in-memory Pocket chain implementing
+// pocket.ChainReader and pocket.TxSubmitter, for tests that exercise the
+// stake, fund and top-up flows without pocketd or a network.
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pokt-network/sam/internal/models"
+	"github.com/pokt-network/sam/internal/pocket"
+)
+
+var (
+	_ pocket.ChainReader = (*Chain)(nil)
+	_ pocket.TxSubmitter = (*Chain)(nil)
+)
+
+// Tx is a transaction recorded by the fake chain.
+type Tx struct {
+	Hash    string
+	Height  int64
+	Kind    string // "stake", "upstake", "fund", "sweep", "authz grant", ...
+	From    string
+	To      string
+	Amount  int64
+	Success bool
+	Error   string
+}
+
+type account struct {
+	number   uint64
+	sequence uint64
+	balance  int64
+	other    []models.Coin // denoms besides uPOKT; transactions don't touch them
+}
+
+// coins returns the account's balances as the bank module lists them:
+// uPOKT, then the other denoms, leaving out zero amounts.
+func (acc *account) coins() []models.Coin {
+	coins := []models.Coin{}
+	if acc == nil {
+		return coins
+	}
+	if acc.balance != 0 {
+		coins = append(coins, models.Coin{Denom: models.Denom, Amount: strconv.FormatInt(acc.balance, 10)})
+	}
+	return append(coins, acc.other...)
+}
+
+type application struct {
+	serviceID string
+	stake     int64
+	gateway   string
+}
+
+type feeAllowance struct {
+	spendLimit *int64
+	expiration *time.Time
+}
+
+// Chain is an in-memory chain. Every transaction is committed in its own
+// block and charges Fee to the signer, or to the fee granter when the signer
+// holds an active allowance. The zero value is not usable; call New.
+type Chain struct {
+	// Fee is the uPOKT charged per transaction.
+	Fee int64
+
+	mu          sync.Mutex
+	height      int64
+	blockTime   time.Time
+	accounts    map[string]*account
+	apps        map[string]*application
+	services    []models.ServiceInfo
+	params      models.ChainParams
+	stakeGrants map[string]*time.Time // granter/grantee -> expiration
+	allowances  map[string]feeAllowance
+	txs         []Tx
+	failNext    map[string]string
+	failQuery   map[string]string // address -> error returned by QueryApplication
+	failBalance map[string]string // address -> error returned by balance queries
+}
+
+// New returns an empty chain at height 1 charging pocket.TxFee per tx.
+func New() *Chain {
+	return &Chain{
+		Fee:         pocket.TxFee,
+		height:      1,
+		blockTime:   time.Now(),
+		accounts:    make(map[string]*account),
+		apps:        make(map[string]*application),
+		stakeGrants: make(map[string]*time.Time),
+		allowances:  make(map[string]feeAllowance),
+		failNext:    make(map[string]string),
+		failQuery:   make(map[string]string),
+		failBalance: make(map[string]string),
+		params: models.ChainParams{
+			Application: models.ApplicationParams{MaxDelegatedGateways: 7},
+			Shared: models.SharedParams{
+				NumBlocksPerSession:                10,
+				GracePeriodEndOffsetBlocks:         1,
+				ClaimWindowOpenOffsetBlocks:        1,
+				ClaimWindowCloseOffsetBlocks:       4,
+				ProofWindowOpenOffsetBlocks:        0,
+				ProofWindowCloseOffsetBlocks:       4,
+				SupplierUnbondingPeriodSessions:    1,
+				ApplicationUnbondingPeriodSessions: 1,
+				ComputeUnitsToTokensMultiplier:     42,
+			},
+			Tokenomics: models.TokenomicsParams{
+				MintAllocationPercentages: map[string]float64{"dao": 0.1, "proposer": 0.05, "supplier": 0.7, "source_owner": 0.15, "application": 0},
+				GlobalInflationPerClaim:   0.1,
+			},
+		},
+	}
+}
+
+// SetMinStake sets the application module's min_stake, which stakes and
+// upstakes must reach. It is 0, no minimum, by default.
+func (c *Chain) SetMinStake(upokt int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.params.Application.MinStake = upokt
+}
+
+// SetBalance sets the liquid uPOKT balance of an address.
+func (c *Chain) SetBalance(address string, amount int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.account(address).balance = amount
+}
+
+// SetCoins sets the balances of an address in denoms other than uPOKT.
+func (c *Chain) SetCoins(address string, coins ...models.Coin) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.account(address).other = append([]models.Coin{}, coins...)
+}
+
+// Balance returns the liquid uPOKT balance of an address.
+func (c *Chain) Balance(address string) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if acc, ok := c.accounts[address]; ok {
+		return acc.balance
+	}
+	return 0
+}
+
+// SetApplication stakes an application directly, without a transaction.
+func (c *Chain) SetApplication(address, serviceID string, stake int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.account(address)
+	c.apps[address] = &application{serviceID: serviceID, stake: stake}
+}
+
+// Stake returns an application's stake, or 0 if it isn't staked.
+func (c *Chain) Stake(address string) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if app, ok := c.apps[address]; ok {
+		return app.stake
+	}
+	return 0
+}
+
+// SetGateway delegates an application to a gateway.
+func (c *Chain) SetGateway(address, gateway string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if app, ok := c.apps[address]; ok {
+		app.gateway = gateway
+	}
+}
+
+// Burn removes up to amount from an application's stake, as relays settled
+// against it would, and returns the new stake.
+func (c *Chain) Burn(address string, amount int64) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	app, ok := c.apps[address]
+	if !ok {
+		return 0
+	}
+	app.stake -= min(amount, app.stake)
+	return app.stake
+}
+
+// AddService registers a service that applications can stake for.
+func (c *Chain) AddService(id, name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.services = append(c.services, models.ServiceInfo{ID: id, Name: name})
+}
+
+// FailNext makes the next transaction of the given kind fail with message.
+func (c *Chain) FailNext(kind, message string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failNext[kind] = message
+}
+
+// FailQuery makes QueryApplication fail for address with message, and
+// ListApplications leave it out, until it is called again with an empty
+// message.
+func (c *Chain) FailQuery(address, message string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if message == "" {
+		delete(c.failQuery, address)
+		return
+	}
+	c.failQuery[address] = message
+}
+
+// FailBalance makes every balance query of address, including the one in
+// QueryApplication, fail with message, until it is called again with an
+// empty message.
+func (c *Chain) FailBalance(address, message string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if message == "" {
+		delete(c.failBalance, address)
+		return
+	}
+	c.failBalance[address] = message
+}
+
+// Txs returns a copy of the transaction log, oldest first.
+func (c *Chain) Txs() []Tx {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Tx(nil), c.txs...)
+}
+
+// Height returns the current block height.
+func (c *Chain) Height() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.height
+}
+
+// NextBlock commits an empty block and returns the new height.
+func (c *Chain) NextBlock() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.height++
+	c.blockTime = time.Now()
+	return c.height
+}
+
+// account returns the account for address, creating it. Callers hold c.mu.
+func (c *Chain) account(address string) *account {
+	acc, ok := c.accounts[address]
+	if !ok {
+		acc = &account{number: uint64(len(c.accounts) + 1)}
+		c.accounts[address] = acc
+	}
+	return acc
+}
+
+// activeAllowance returns the allowance granter→grantee if it can pay fee.
+// Callers hold c.mu.
+func (c *Chain) activeAllowance(grantee string) (string, bool) {
+	for key, a := range c.allowances {
+		granter, g := splitKey(key)
+		if g != grantee {
+			continue
+		}
+		if a.expiration != nil && !a.expiration.After(time.Now()) {
+			continue
+		}
+		if a.spendLimit != nil && *a.spendLimit < c.Fee {
+			continue
+		}
+		return granter, true
+	}
+	return "", false
+}
+
+// commit runs apply as a transaction signed by signer: it charges the fee,
+// applies the state change, bumps the signer's sequence and records the tx.
+// Callers hold c.mu.
+func (c *Chain) commit(kind, signer, to string, amount int64, apply func() error) *models.TransactionResponse {
+	c.height++
+	c.blockTime = time.Now()
+	tx := Tx{
+		Hash:   fmt.Sprintf("%064X", len(c.txs)+1),
+		Height: c.height,
+		Kind:   kind,
+		From:   signer,
+		To:     to,
+		Amount: amount,
+	}
+
+	err := c.chargeFee(signer)
+	if err == nil {
+		if msg, ok := c.failNext[kind]; ok {
+			delete(c.failNext, kind)
+			err = fmt.Errorf("%s", msg)
+		}
+	}
+	if err == nil {
+		err = apply()
+	}
+	c.account(signer).sequence++
+
+	if err != nil {
+		tx.Error = err.Error()
+		c.txs = append(c.txs, tx)
+		return &models.TransactionResponse{Success: false, Message: kind + " transaction failed"}
+	}
+
+	tx.Success = true
+	c.txs = append(c.txs, tx)
+	return &models.TransactionResponse{TxHash: tx.Hash, Success: true}
+}
+
+// chargeFee takes the fee from the signer's fee granter or the signer.
+// Callers hold c.mu.
+func (c *Chain) chargeFee(signer string) error {
+	if granter, ok := c.activeAllowance(signer); ok {
+		key := grantKey(granter, signer)
+		a := c.allowances[key]
+		if a.spendLimit != nil {
+			remaining := *a.spendLimit - c.Fee
+			a.spendLimit = &remaining
+			c.allowances[key] = a
+		}
+		return c.debit(granter, c.Fee)
+	}
+	return c.debit(signer, c.Fee)
+}
+
+// debit removes amount from address. Callers hold c.mu.
+func (c *Chain) debit(address string, amount int64) error {
+	acc := c.account(address)
+	if acc.balance < amount {
+		return fmt.Errorf("insufficient funds: %s has %dupokt, needs %dupokt", address, acc.balance, amount)
+	}
+	acc.balance -= amount
+	return nil
+}
+
+// send moves amount from one address to another. Callers hold c.mu.
+func (c *Chain) send(from, to string, amount int64) error {
+	if amount <= 0 {
+		return fmt.Errorf("send amount must be positive")
+	}
+	if err := c.debit(from, amount); err != nil {
+		return err
+	}
+	c.account(to).balance += amount
+	return nil
+}
+
+// stake sets an application's stake to newStake, taking the increase from
+// its liquid balance. Callers hold c.mu.
+func (c *Chain) stake(address, serviceID string, newStake int64) error {
+	app, ok := c.apps[address]
+	current := int64(0)
+	if ok {
+		current = app.stake
+	}
+	if newStake <= current {
+		return fmt.Errorf("stake %d must be greater than current stake %d", newStake, current)
+	}
+	if minStake := c.params.Application.MinStake; newStake < minStake {
+		return fmt.Errorf("stake %d is below the minimum stake %d", newStake, minStake)
+	}
+	if err := c.debit(address, newStake-current); err != nil {
+		return err
+	}
+	if !ok {
+		app = &application{}
+		c.apps[address] = app
+	}
+	app.serviceID = serviceID
+	app.stake = newStake
+	return nil
+}
+
+func grantKey(granter, grantee string) string {
+	return granter + "/" + grantee
+}
+
+func splitKey(key string) (string, string) {
+	granter, grantee, _ := strings.Cut(key, "/")
+	return granter, grantee
+}
+
+// ChainReader
+
+// QueryBalance implements pocket.ChainReader.
+func (c *Chain) QueryBalance(_ context.Context, address, _, _ string) (int64, error) {
+	c.mu.Lock()
+	msg, failing := c.failBalance[address]
+	c.mu.Unlock()
+	if failing {
+		return 0, errors.New(msg)
+	}
+	return c.Balance(address), nil
+}
+
+// QueryApplication implements pocket.ChainReader.
+func (c *Chain) QueryApplication(_ context.Context, address, _, network, _ string) (*models.Application, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if msg, ok := c.failQuery[address]; ok {
+		return nil, errors.New(msg)
+	}
+	if msg, ok := c.failBalance[address]; ok {
+		return nil, fmt.Errorf("failed to query balance: %s", msg)
+	}
+	app, ok := c.apps[address]
+	if !ok {
+		return nil, fmt.Errorf("application not found: %s", address)
+	}
+	result := app.model(address, network)
+	result.LiquidBalance = c.account(address).balance
+	result.Denom = models.Denom
+	result.Balances = c.account(address).coins()
+	return result, nil
+}
+
+// model converts app to a models.Application without its balance.
+func (app *application) model(address, network string) *models.Application {
+	result := &models.Application{
+		Address:    address,
+		ServiceID:  app.serviceID,
+		ServiceIDs: []string{app.serviceID},
+		Stake:      app.stake,
+		Gateway:    app.gateway,
+		Gateways:   []string{},
+		Network:    network,
+	}
+	if app.gateway != "" {
+		result.Gateways = append(result.Gateways, app.gateway)
+	}
+	return result
+}
+
+// ListApplications implements pocket.ChainReader. Apps are listed in
+// address order; ones with a FailQuery error are left out, as if the node
+// had not indexed them, so callers fall back to QueryApplication.
+func (c *Chain) ListApplications(_ context.Context, _, network, gateway string) ([]*models.Application, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	addresses := make([]string, 0, len(c.apps))
+	for address, app := range c.apps {
+		if _, failing := c.failQuery[address]; failing {
+			continue
+		}
+		if gateway != "" && app.gateway != gateway {
+			continue
+		}
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	apps := make([]*models.Application, 0, len(addresses))
+	for _, address := range addresses {
+		apps = append(apps, c.apps[address].model(address, network))
+	}
+	return apps, nil
+}
+
+// QueryBalances implements pocket.ChainReader. Addresses with a FailBalance
+// error are missing from the map and listed in the error.
+func (c *Chain) QueryBalances(_ context.Context, addresses []string, _ string) (map[string][]models.Coin, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	balances := make(map[string][]models.Coin, len(addresses))
+	var failed []error
+	for _, address := range addresses {
+		if msg, ok := c.failBalance[address]; ok {
+			failed = append(failed, fmt.Errorf("%s: %s", address, msg))
+			continue
+		}
+		balances[address] = c.accounts[address].coins()
+	}
+	return balances, errors.Join(failed...)
+}
+
+// QueryAllBalances implements pocket.ChainReader.
+func (c *Chain) QueryAllBalances(_ context.Context, address, _ string) ([]models.Coin, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if msg, ok := c.failBalance[address]; ok {
+		return nil, errors.New(msg)
+	}
+	return c.accounts[address].coins(), nil
+}
+
+// QueryServices implements pocket.ChainReader.
+func (c *Chain) QueryServices(_ context.Context, _ string) ([]models.ServiceInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]models.ServiceInfo{}, c.services...), nil
+}
+
+// QueryLatestBlock implements pocket.ChainReader.
+func (c *Chain) QueryLatestBlock(_ context.Context, _ string) (*models.NodeStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &models.NodeStatus{Height: c.height, BlockTime: c.blockTime}, nil
+}
+
+// QueryApplicationParams implements pocket.ChainReader.
+func (c *Chain) QueryApplicationParams(_ context.Context, _ string) (*models.ApplicationParams, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	params := c.params.Application
+	return &params, nil
+}
+
+// QuerySharedParams implements pocket.ChainReader.
+func (c *Chain) QuerySharedParams(_ context.Context, _ string) (*models.SharedParams, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	params := c.params.Shared
+	return &params, nil
+}
+
+// QueryTokenomicsParams implements pocket.ChainReader.
+func (c *Chain) QueryTokenomicsParams(_ context.Context, _ string) (*models.TokenomicsParams, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	params := c.params.Tokenomics
+	params.MintAllocationPercentages = maps.Clone(params.MintAllocationPercentages)
+	return &params, nil
+}
+
+// QueryBankAccount implements pocket.ChainReader.
+func (c *Chain) QueryBankAccount(_ context.Context, address, _, network, _ string) (*models.BankAccount, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	acc := c.accounts[address]
+	var balance int64
+	if acc != nil {
+		balance = acc.balance
+	}
+	return &models.BankAccount{
+		Address:  address,
+		Balance:  balance,
+		Denom:    models.Denom,
+		Balances: acc.coins(),
+		Network:  network,
+	}, nil
+}
+
+// QueryTx implements pocket.ChainReader.
+func (c *Chain) QueryTx(_ context.Context, txHash, _ string) (*models.APITxResponse, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, tx := range c.txs {
+		if tx.Hash != txHash {
+			continue
+		}
+		var resp models.APITxResponse
+		resp.TxResponse.TxHash = tx.Hash
+		resp.TxResponse.Height = strconv.FormatInt(tx.Height, 10)
+		if !tx.Success {
+			resp.TxResponse.Code = 1
+			resp.TxResponse.RawLog = tx.Error
+		}
+		return &resp, true, nil
+	}
+	return nil, false, nil
+}
+
+// QueryAccount implements pocket.ChainReader.
+func (c *Chain) QueryAccount(_ context.Context, address, _ string) (uint64, uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	acc, ok := c.accounts[address]
+	if !ok {
+		return 0, 0, fmt.Errorf("account %s not found", address)
+	}
+	return acc.number, acc.sequence, nil
+}
+
+// QueryStakeGrant implements pocket.ChainReader.
+func (c *Chain) QueryStakeGrant(_ context.Context, granter, grantee, _ string) (*models.AuthzGrant, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stakeGrant(granter, grantee), nil
+}
+
+// ListStakeGrants implements pocket.ChainReader.
+func (c *Chain) ListStakeGrants(_ context.Context, grantee, _ string) (map[string]*models.AuthzGrant, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	grants := make(map[string]*models.AuthzGrant)
+	for key := range c.stakeGrants {
+		if granter, to := splitKey(key); to == grantee {
+			grants[granter] = c.stakeGrant(granter, grantee)
+		}
+	}
+	return grants, nil
+}
+
+// stakeGrant returns the granter→grantee stake grant, or nil. Callers must
+// hold mu.
+func (c *Chain) stakeGrant(granter, grantee string) *models.AuthzGrant {
+	expiration, ok := c.stakeGrants[grantKey(granter, grantee)]
+	if !ok {
+		return nil
+	}
+	return &models.AuthzGrant{
+		Granter:    granter,
+		Grantee:    grantee,
+		MsgTypeURL: pocket.MsgStakeApplicationType,
+		Expiration: expiration,
+		Active:     expiration == nil || expiration.After(time.Now()),
+	}
+}
+
+// QueryFeeAllowance implements pocket.ChainReader.
+func (c *Chain) QueryFeeAllowance(_ context.Context, granter, grantee, _, _ string) (*models.FeeAllowance, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.feeAllowance(granter, grantee), nil
+}
+
+// ListFeeAllowances implements pocket.ChainReader.
+func (c *Chain) ListFeeAllowances(_ context.Context, granter, _, _ string) (map[string]*models.FeeAllowance, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	allowances := make(map[string]*models.FeeAllowance)
+	for key := range c.allowances {
+		if from, grantee := splitKey(key); from == granter {
+			allowances[grantee] = c.feeAllowance(granter, grantee)
+		}
+	}
+	return allowances, nil
+}
+
+// feeAllowance returns the granter→grantee fee allowance, or nil. Callers
+// must hold mu.
+func (c *Chain) feeAllowance(granter, grantee string) *models.FeeAllowance {
+	a, ok := c.allowances[grantKey(granter, grantee)]
+	if !ok {
+		return nil
+	}
+	allowance := &models.FeeAllowance{
+		Granter:    granter,
+		Grantee:    grantee,
+		Expiration: a.expiration,
+		Active:     a.expiration == nil || a.expiration.After(time.Now()),
+	}
+	if a.spendLimit != nil {
+		remaining := *a.spendLimit
+		allowance.SpendLimit = &remaining
+		if remaining < c.Fee {
+			allowance.Active = false
+		}
+	}
+	return allowance
+}
+
+// TxSubmitter
+
+// StakeNewApplication implements pocket.TxSubmitter.
+func (c *Chain) StakeNewApplication(_ context.Context, appAddress, serviceID, _ string, amountUpokt int64, _ string) (*models.TransactionResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.commit("stake", appAddress, "", amountUpokt, func() error {
+		if _, ok := c.apps[appAddress]; ok {
+			return fmt.Errorf("application %s is already staked", appAddress)
+		}
+		known := false
+		for _, s := range c.services {
+			if s.ID == serviceID {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("service %s not found", serviceID)
+		}
+		return c.stake(appAddress, serviceID, amountUpokt)
+	}), nil
+}
+
+// UpstakeApplication implements pocket.TxSubmitter. Like the Executor, it
+// goes through the bank when the bank holds an active stake grant.
+func (c *Chain) UpstakeApplication(_ context.Context, appAddress, bankAddress, _ string, amount int64, _, _ string) (*models.TransactionResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	app, ok := c.apps[appAddress]
+	if !ok {
+		return nil, fmt.Errorf("failed to query application before upstake: application not found: %s", appAddress)
+	}
+
+	signer := appAddress
+	if exp, ok := c.stakeGrants[grantKey(appAddress, bankAddress)]; ok && (exp == nil || exp.After(time.Now())) {
+		signer = bankAddress
+	}
+
+	return c.commit("upstake", signer, appAddress, amount, func() error {
+		return c.stake(appAddress, app.serviceID, app.stake+amount)
+	}), nil
+}
+
+// FundApplication implements pocket.TxSubmitter.
+func (c *Chain) FundApplication(_ context.Context, appAddress, bankAddress, _ string, amount int64, _ string) (*models.TransactionResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.commit("fund", bankAddress, appAddress, amount, func() error {
+		return c.send(bankAddress, appAddress, amount)
+	}), nil
+}
+
+// SweepApplication implements pocket.TxSubmitter.
+func (c *Chain) SweepApplication(_ context.Context, appAddress, bankAddress, _ string, amount int64, _ string) (*models.TransactionResponse, error) {
+	if amount <= 0 {
+		return nil, fmt.Errorf("sweep amount must be positive")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.commit("sweep", appAddress, bankAddress, amount, func() error {
+		return c.send(appAddress, bankAddress, amount)
+	}), nil
+}
+
+// GrantStakeAuthz implements pocket.TxSubmitter.
+func (c *Chain) GrantStakeAuthz(_ context.Context, appAddress, bankAddress, _ string, expirationDays int, _ string) (*models.TransactionResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.commit("authz grant", appAddress, bankAddress, 0, func() error {
+		c.stakeGrants[grantKey(appAddress, bankAddress)] = expiry(expirationDays)
+		return nil
+	}), nil
+}
+
+// RevokeStakeAuthz implements pocket.TxSubmitter.
+func (c *Chain) RevokeStakeAuthz(_ context.Context, appAddress, bankAddress, _, _ string) (*models.TransactionResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.commit("authz revoke", appAddress, bankAddress, 0, func() error {
+		key := grantKey(appAddress, bankAddress)
+		if _, ok := c.stakeGrants[key]; !ok {
+			return fmt.Errorf("authorization not found")
+		}
+		delete(c.stakeGrants, key)
+		return nil
+	}), nil
+}
+
+// GrantFeeAllowance implements pocket.TxSubmitter.
+func (c *Chain) GrantFeeAllowance(_ context.Context, appAddress, bankAddress, _ string, spendLimit int64, expirationDays int, _ string) (*models.TransactionResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.commit("feegrant grant", bankAddress, appAddress, 0, func() error {
+		a := feeAllowance{expiration: expiry(expirationDays)}
+		if spendLimit > 0 {
+			a.spendLimit = &spendLimit
+		}
+		c.allowances[grantKey(bankAddress, appAddress)] = a
+		return nil
+	}), nil
+}
+
+// RevokeFeeAllowance implements pocket.TxSubmitter.
+func (c *Chain) RevokeFeeAllowance(_ context.Context, appAddress, bankAddress, _, _ string) (*models.TransactionResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.commit("feegrant revoke", bankAddress, appAddress, 0, func() error {
+		key := grantKey(bankAddress, appAddress)
+		if _, ok := c.allowances[key]; !ok {
+			return fmt.Errorf("fee-grant not found")
+		}
+		delete(c.allowances, key)
+		return nil
+	}), nil
+}
+
+// HasFeeGrant implements pocket.TxSubmitter.
+func (c *Chain) HasFeeGrant(_ context.Context, appAddress, _ string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.activeAllowance(appAddress)
+	return ok
+}
+
+// signedSend is the part of a signed bank send BroadcastSigned understands.
+type signedSend struct {
+	Body struct {
+		Messages []struct {
+			Type        string        `json:"@type"`
+			FromAddress string        `json:"from_address"`
+			ToAddress   string        `json:"to_address"`
+			Amount      []models.Coin `json:"amount"`
+		} `json:"messages"`
+	} `json:"body"`
+	Signatures []string `json:"signatures"`
+}
+
+// BroadcastSigned implements pocket.TxSubmitter. It applies single bank
+// sends; signatures are required but not verified.
+func (c *Chain) BroadcastSigned(_ context.Context, signedTx []byte, _, _ string) (*models.TransactionResponse, error) {
+	var tx signedSend
+	if err := json.Unmarshal(signedTx, &tx); err != nil {
+		return nil, fmt.Errorf("failed to parse signed tx: %w", err)
+	}
+	if len(tx.Signatures) == 0 {
+		return &models.TransactionResponse{Success: false, Message: "broadcast failed"}, nil
+	}
+	if len(tx.Body.Messages) != 1 || tx.Body.Messages[0].Type != "/cosmos.bank.v1beta1.MsgSend" {
+		return nil, fmt.Errorf("fake chain only broadcasts single bank sends")
+	}
+
+	msg := tx.Body.Messages[0]
+	var amount int64
+	for _, coin := range msg.Amount {
+		if coin.Denom == models.Denom {
+			n, err := coin.Upokt()
+			if err != nil {
+				return nil, fmt.Errorf("invalid amount: %w", err)
+			}
+			amount = n
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.commit("broadcast", msg.FromAddress, msg.ToAddress, amount, func() error {
+		return c.send(msg.FromAddress, msg.ToAddress, amount)
+	}), nil
+}
+
+// Multisign implements pocket.TxSubmitter. It attaches the collected
+// signatures in signer order without combining them.
+func (c *Chain) Multisign(_ context.Context, tx models.PendingTx, _, _ string) ([]byte, error) {
+	if len(tx.Signatures) < tx.Threshold {
+		return nil, fmt.Errorf("have %d of %d required signatures", len(tx.Signatures), tx.Threshold)
+	}
+
+	var unsigned map[string]any
+	if err := json.Unmarshal([]byte(tx.UnsignedTx), &unsigned); err != nil {
+		return nil, fmt.Errorf("failed to parse unsigned tx: %w", err)
+	}
+	sigs := make([]string, 0, len(tx.Signatures))
+	for _, signer := range tx.Signers {
+		if sig, ok := tx.Signatures[signer]; ok {
+			sigs = append(sigs, sig)
+		}
+	}
+	unsigned["signatures"] = sigs
+	return json.Marshal(unsigned)
+}
+
+// KeyringStatus implements pocket.TxSubmitter. The fake chain has no keyring.
+func (c *Chain) KeyringStatus() *pocket.KeyringStatus {
+	return nil
+}
+
+func expiry(days int) *time.Time {
+	if days <= 0 {
+		return nil
+	}
+	t := time.Now().AddDate(0, 0, days)
+	return &t
+}
@@ -0,0 +1,207 @@
+package pocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pokt-network/sam/internal/models"
+)
+
+// TxFee is the fee SAM pays for every transaction, in base units of the
+// network's denom (--fees=1upokt on mainnet).
+const TxFee int64 = 1
+
+// QueryFeeAllowance returns the granter→grantee fee allowance, or nil if none
+// exists. Its spend limit is read in denom, the network's base denom.
+func (c *Client) QueryFeeAllowance(ctx context.Context, granter, grantee, apiEndpoint, denom string) (*models.FeeAllowance, error) {
+	path := fmt.Sprintf("/cosmos/feegrant/v1beta1/allowance/%s/%s", granter, grantee)
+	c.Logger.Debug("querying fee allowance", "endpoint", apiEndpoint, "path", path)
+
+	resp, err := c.get(ctx, apiEndpoint, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query feegrant API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+		return nil, fmt.Errorf("feegrant API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feegrant response: %w", err)
+	}
+
+	var apiResp models.APIFeeAllowanceResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to parse feegrant response: %w", err)
+	}
+
+	return c.feeAllowance(granter, grantee, apiResp.Allowance.Allowance, denom)
+}
+
+// ListFeeAllowances returns the fee allowances issued by granter, keyed by
+// grantee, with one paged listing instead of a query per grantee.
+func (c *Client) ListFeeAllowances(ctx context.Context, granter, apiEndpoint, denom string) (map[string]*models.FeeAllowance, error) {
+	entries, err := listAll(ctx, c, apiEndpoint, "/cosmos/feegrant/v1beta1/issued/"+granter, nil, "fee allowances",
+		func(p *models.APIFeeAllowancesResponse) ([]models.APIFeeGrant, models.APIPagination) {
+			return p.Allowances, p.Pagination
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	allowances := make(map[string]*models.FeeAllowance, len(entries))
+	for _, entry := range entries {
+		allowance, err := c.feeAllowance(granter, entry.Grantee, entry.Allowance, denom)
+		if err != nil {
+			return nil, fmt.Errorf("fee allowance for %s: %w", entry.Grantee, err)
+		}
+		allowances[entry.Grantee] = allowance
+	}
+	return allowances, nil
+}
+
+// feeAllowance converts a feegrant allowance, reading its spend limit in
+// denom.
+func (c *Client) feeAllowance(granter, grantee string, apiAllowance models.APIFeeAllowance, denom string) (*models.FeeAllowance, error) {
+	// Unwrap AllowedMsgAllowance / PeriodicAllowance down to the basic allowance.
+	basic := &apiAllowance
+	for basic.Allowance != nil || basic.Basic != nil {
+		if basic.Allowance != nil {
+			basic = basic.Allowance
+		} else {
+			basic = basic.Basic
+		}
+	}
+
+	allowance := &models.FeeAllowance{
+		Granter:    granter,
+		Grantee:    grantee,
+		Expiration: basic.Expiration,
+		Active:     basic.Expiration == nil || basic.Expiration.After(time.Now()),
+	}
+
+	if len(basic.SpendLimit) > 0 {
+		var remaining int64
+		for _, coin := range basic.SpendLimit {
+			if coin.Denom == denom {
+				var err error
+				remaining, err = coin.Upokt()
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse spend limit: %w", err)
+				}
+			}
+		}
+		allowance.SpendLimit = &remaining
+		if remaining < TxFee {
+			allowance.Active = false
+		}
+	}
+
+	return allowance, nil
+}
+
+// GrantFeeAllowance creates a bank→app fee allowance capped at spendLimit uPOKT.
+// For an offline or multisig bank it is generated unsigned and left pending,
+// like FundApplication.
+func (e *Executor) GrantFeeAllowance(ctx context.Context, appAddress, bankAddress, network string, spendLimit int64, expirationDays int, rpcEndpoint string) (*models.TransactionResponse, error) {
+	if spendLimit <= 0 {
+		return nil, fmt.Errorf("spend limit must be positive")
+	}
+	if expirationDays < 0 {
+		return nil, fmt.Errorf("expiration days must not be negative")
+	}
+
+	e.Logger.Info("granting fee allowance", "app", appAddress, "bank", bankAddress, "spend_limit", spendLimit)
+
+	args := []string{
+		"tx", "feegrant", "grant",
+		bankAddress,
+		appAddress,
+		"--spend-limit", e.coins(network, spendLimit),
+		"--node", rpcEndpoint,
+		"--chain-id", network,
+		e.feesFlag(network),
+		"--output", "json",
+	}
+
+	if expirationDays > 0 {
+		exp := time.Now().AddDate(0, 0, expirationDays).UTC().Format(time.RFC3339)
+		args = append(args, "--expiration", exp)
+	}
+
+	if netCfg, ok := e.Config.Config.Networks[network]; ok && netCfg.OfflineBank() {
+		args = append(args, "--generate-only", "--gas", generateOnlyGas)
+		tx := models.PendingTx{Network: network, Kind: "feegrant grant", From: bankAddress, To: appAddress, Amount: spendLimit}
+		return e.generatePending(ctx, args, tx, netCfg)
+	}
+	args = append(args, "--from", bankAddress, "--yes", "--gas=auto")
+
+	if e.Config.Config.KeyringBackend != "" {
+		args = append(args, "--keyring-backend", e.Config.Config.KeyringBackend)
+	}
+
+	return e.runTx(ctx, "feegrant grant", bankAddress, args)
+}
+
+// RevokeFeeAllowance removes the bank→app fee allowance. For an offline or
+// multisig bank it is generated unsigned and left pending.
+func (e *Executor) RevokeFeeAllowance(ctx context.Context, appAddress, bankAddress, network, rpcEndpoint string) (*models.TransactionResponse, error) {
+	e.Logger.Info("revoking fee allowance", "app", appAddress, "bank", bankAddress)
+
+	args := []string{
+		"tx", "feegrant", "revoke",
+		bankAddress,
+		appAddress,
+		"--node", rpcEndpoint,
+		"--chain-id", network,
+		e.feesFlag(network),
+		"--output", "json",
+	}
+
+	if netCfg, ok := e.Config.Config.Networks[network]; ok && netCfg.OfflineBank() {
+		args = append(args, "--generate-only", "--gas", generateOnlyGas)
+		tx := models.PendingTx{Network: network, Kind: "feegrant revoke", From: bankAddress, To: appAddress}
+		return e.generatePending(ctx, args, tx, netCfg)
+	}
+	args = append(args, "--from", bankAddress, "--yes", "--gas=auto")
+
+	if e.Config.Config.KeyringBackend != "" {
+		args = append(args, "--keyring-backend", e.Config.Config.KeyringBackend)
+	}
+
+	return e.runTx(ctx, "feegrant revoke", bankAddress, args)
+}
+
+// HasFeeGrant reports whether the network's bank currently pays fees for appAddress.
+func (e *Executor) HasFeeGrant(ctx context.Context, appAddress, network string) bool {
+	netCfg, ok := e.Config.Config.Networks[network]
+	if !ok || netCfg.Bank == "" {
+		return false
+	}
+
+	allowance, err := e.Client.QueryFeeAllowance(ctx, netCfg.Bank, appAddress, netCfg.APIEndpoint, netCfg.BaseDenom())
+	if err != nil {
+		e.Logger.Warn("failed to query fee allowance", "address", appAddress, "error", err)
+		return false
+	}
+	return allowance != nil && allowance.Active
+}
+
+// feeGranterArgs returns --fee-granter for transactions signed by appAddress
+// when the bank holds an active fee allowance for it.
+func (e *Executor) feeGranterArgs(ctx context.Context, appAddress, network string) []string {
+	if !e.HasFeeGrant(ctx, appAddress, network) {
+		return nil
+	}
+	return []string{"--fee-granter", e.Config.Config.Networks[network].Bank}
+}
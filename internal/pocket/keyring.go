@@ -0,0 +1,123 @@
+package pocket
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultKeyringCheckInterval is how often RunKeyringChecks re-checks the
+// keyring, so a fixed (or revoked) passphrase shows up without a restart.
+const DefaultKeyringCheckInterval = time.Minute
+
+// KeyringStatus is the result of the last keyring unlock check.
+type KeyringStatus struct {
+	OK        bool      `json:"ok"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// keyringState guards the last KeyringStatus.
+type keyringState struct {
+	mu     sync.RWMutex
+	status *KeyringStatus
+}
+
+// passphrase returns the configured keyring passphrase, or "" when none is
+// configured. It is re-read on every call so rotated secrets are picked up.
+func (e *Executor) passphrase() (string, error) {
+	c := e.Config.Config
+	switch {
+	case c.KeyringPassphraseFile != "":
+		data, err := os.ReadFile(c.KeyringPassphraseFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read keyring passphrase file: %w", err)
+		}
+		pass := strings.TrimRight(string(data), "\r\n")
+		if pass == "" {
+			return "", fmt.Errorf("keyring passphrase file is empty")
+		}
+		return pass, nil
+	case c.KeyringPassphraseEnv != "":
+		pass := os.Getenv(c.KeyringPassphraseEnv)
+		if pass == "" {
+			return "", fmt.Errorf("keyring passphrase env var %s is not set", c.KeyringPassphraseEnv)
+		}
+		return pass, nil
+	}
+	return "", nil
+}
+
+// CheckKeyring verifies that the keyring can be opened (pocketd keys list)
+// with the configured backend and passphrase, and records the result for
+// KeyringStatus. A check aborted by ctx leaves the status unchanged.
+func (e *Executor) CheckKeyring(ctx context.Context) error {
+	args := []string{"keys", "list", "--output", "json"}
+	if e.Config.Config.KeyringBackend != "" {
+		args = append(args, "--keyring-backend", e.Config.Config.KeyringBackend)
+	}
+
+	if _, err := e.Run(ctx, args...); err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+		e.setKeyringStatus(false)
+		return fmt.Errorf("keyring could not be unlocked: %w", err)
+	}
+	e.setKeyringStatus(true)
+	return nil
+}
+
+// RunKeyringChecks re-checks the keyring every interval until ctx is
+// cancelled, logging when its status changes.
+func (e *Executor) RunKeyringChecks(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			before := e.KeyringStatus()
+			wasOK := before != nil && before.OK
+			err := e.CheckKeyring(ctx)
+			switch {
+			case ctx.Err() != nil:
+				return
+			case err != nil && wasOK:
+				e.Logger.Error("keyring check failed; transactions will fail until it can be unlocked", "error", err)
+			case err == nil && !wasOK:
+				e.Logger.Info("keyring unlocked", "backend", e.Config.Config.KeyringBackend)
+			}
+		}
+	}
+}
+
+// setKeyringStatus records whether the keyring could last be unlocked.
+func (e *Executor) setKeyringStatus(ok bool) {
+	status := &KeyringStatus{OK: ok, CheckedAt: time.Now()}
+	if !ok {
+		status.Error = "keyring could not be unlocked"
+	}
+
+	e.keyring.mu.Lock()
+	e.keyring.status = status
+	e.keyring.mu.Unlock()
+}
+
+// KeyringStatus returns the result of the last keyring check or keyring-signed
+// transaction, or nil if neither has run.
+func (e *Executor) KeyringStatus() *KeyringStatus {
+	e.keyring.mu.RLock()
+	defer e.keyring.mu.RUnlock()
+
+	if e.keyring.status == nil {
+		return nil
+	}
+	status := *e.keyring.status
+	return &status
+}
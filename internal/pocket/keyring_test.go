@@ -0,0 +1,145 @@
+package pocket
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pokt-network/sam/internal/config"
+)
+
+// fakePocketd writes a script that succeeds only when stdin carries the
+// expected passphrase, and never echoes it.
+func fakePocketd(t *testing.T, want string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "pocketd")
+	script := "#!/bin/sh\nread -r pass\n[ \"$pass\" = \"" + want + "\" ] && echo '[]' && exit 0\necho 'wrong passphrase'\nexit 1\n"
+	if err := os.WriteFile(path, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func newKeyringTestExecutor(t *testing.T, cfg *config.Config) *Executor {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	e := NewExecutor(cfg, NewClient(logger), nil, logger)
+	e.Binary = fakePocketd(t, "s3cret")
+	return e
+}
+
+func TestCheckKeyring_PassphraseFile(t *testing.T) {
+	passFile := filepath.Join(t.TempDir(), "passphrase")
+	os.WriteFile(passFile, []byte("s3cret\n"), 0600)
+
+	cfg := &config.Config{}
+	cfg.Config.KeyringBackend = "file"
+	cfg.Config.KeyringPassphraseFile = passFile
+
+	e := newKeyringTestExecutor(t, cfg)
+	if err := e.CheckKeyring(context.Background()); err != nil {
+		t.Fatalf("CheckKeyring() error = %v", err)
+	}
+	if status := e.KeyringStatus(); status == nil || !status.OK {
+		t.Errorf("KeyringStatus() = %+v, want OK", status)
+	}
+}
+
+func TestCheckKeyring_PassphraseEnv(t *testing.T) {
+	t.Setenv("SAM_TEST_KEYRING_PASS", "wrong")
+
+	cfg := &config.Config{}
+	cfg.Config.KeyringBackend = "file"
+	cfg.Config.KeyringPassphraseEnv = "SAM_TEST_KEYRING_PASS"
+
+	e := newKeyringTestExecutor(t, cfg)
+	if err := e.CheckKeyring(context.Background()); err == nil {
+		t.Fatal("expected error for wrong passphrase")
+	}
+	status := e.KeyringStatus()
+	if status == nil || status.OK {
+		t.Fatalf("KeyringStatus() = %+v, want failure", status)
+	}
+
+	t.Setenv("SAM_TEST_KEYRING_PASS", "s3cret")
+	if err := e.CheckKeyring(context.Background()); err != nil {
+		t.Fatalf("CheckKeyring() after fixing passphrase error = %v", err)
+	}
+}
+
+func TestPassphrase_Missing(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Config.KeyringPassphraseEnv = "SAM_TEST_UNSET_PASS"
+
+	e := newKeyringTestExecutor(t, cfg)
+	if _, err := e.passphrase(); err == nil {
+		t.Error("expected error when the passphrase env var is unset")
+	}
+
+	cfg.Config.KeyringPassphraseEnv = ""
+	if pass, err := e.passphrase(); err != nil || pass != "" {
+		t.Errorf("passphrase() = %q, %v; want empty with no source configured", pass, err)
+	}
+}
+
+func TestRunTx_UpdatesKeyringStatus(t *testing.T) {
+	t.Setenv("SAM_TEST_KEYRING_PASS", "s3cret")
+
+	cfg := &config.Config{}
+	cfg.Config.KeyringBackend = "file"
+	cfg.Config.KeyringPassphraseEnv = "SAM_TEST_KEYRING_PASS"
+
+	e := newKeyringTestExecutor(t, cfg)
+	ctx := context.Background()
+
+	if resp, err := e.runTx(ctx, "Test", "pokt1app", []string{"tx", "test"}); err != nil || !resp.Success {
+		t.Fatalf("runTx() = %+v, %v; want success", resp, err)
+	}
+	if status := e.KeyringStatus(); status == nil || !status.OK {
+		t.Fatalf("KeyringStatus() after a signed tx = %+v, want OK", status)
+	}
+
+	// A failed transaction re-checks the keyring, which now fails too.
+	t.Setenv("SAM_TEST_KEYRING_PASS", "rotated")
+	if resp, _ := e.runTx(ctx, "Test", "pokt1app", []string{"tx", "test"}); resp.Success {
+		t.Fatal("runTx() succeeded with the wrong passphrase")
+	}
+	if status := e.KeyringStatus(); status == nil || status.OK {
+		t.Errorf("KeyringStatus() after a failed tx = %+v, want failure", status)
+	}
+}
+
+func TestRunKeyringChecks_Recovers(t *testing.T) {
+	t.Setenv("SAM_TEST_KEYRING_PASS", "wrong")
+
+	cfg := &config.Config{}
+	cfg.Config.KeyringBackend = "file"
+	cfg.Config.KeyringPassphraseEnv = "SAM_TEST_KEYRING_PASS"
+
+	e := newKeyringTestExecutor(t, cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := e.CheckKeyring(ctx); err == nil {
+		t.Fatal("expected error for wrong passphrase")
+	}
+
+	// Fixing the secret is picked up by the next periodic check.
+	t.Setenv("SAM_TEST_KEYRING_PASS", "s3cret")
+	go e.RunKeyringChecks(ctx, 10*time.Millisecond)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if status := e.KeyringStatus(); status != nil && status.OK {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("KeyringStatus() = %+v, want OK after the passphrase is fixed", e.KeyringStatus())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,111 @@
+package pocket
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pokt-network/sam/internal/config"
+)
+
+// Outbound query limits, used for networks that don't set their own.
+const (
+	DefaultRequestsPerSecond = 10
+	DefaultBurst             = 20
+	DefaultConcurrency       = 8
+)
+
+// Limits are the effective outbound query limits of a network: a token
+// bucket per endpoint and a cap on requests in flight across the network.
+type Limits struct {
+	RequestsPerSecond float64
+	Burst             int
+	Concurrency       int
+}
+
+// LimitsFor returns the network's configured limits, falling back to the
+// defaults for unset fields.
+func LimitsFor(netCfg config.NetworkConfig) Limits {
+	l := Limits{
+		RequestsPerSecond: DefaultRequestsPerSecond,
+		Burst:             DefaultBurst,
+		Concurrency:       DefaultConcurrency,
+	}
+	if netCfg.Limits.RequestsPerSecond > 0 {
+		l.RequestsPerSecond = netCfg.Limits.RequestsPerSecond
+	}
+	if netCfg.Limits.Burst > 0 {
+		l.Burst = netCfg.Limits.Burst
+	}
+	if netCfg.Limits.Concurrency > 0 {
+		l.Concurrency = netCfg.Limits.Concurrency
+	}
+	return l
+}
+
+// tokenBucket allows rate requests per second on average with bursts of up
+// to burst. A nil bucket is unlimited.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if rate <= 0 {
+		return nil
+	}
+	b := float64(max(burst, 1))
+	return &tokenBucket{rate: rate, burst: b, tokens: b, last: time.Now()}
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		if err := sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// ForEach calls fn for every item on at most limit goroutines and returns
+// once all calls have returned. Items not yet started when ctx is cancelled
+// are skipped.
+func ForEach[T any](ctx context.Context, limit int, items []T, fn func(ctx context.Context, i int, item T)) {
+	limit = max(limit, 1)
+	sem := make(chan struct{}, limit)
+
+	var wg sync.WaitGroup
+	for i, item := range items {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(ctx, i, item)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,120 @@
+package pocket
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pokt-network/sam/internal/config"
+	"github.com/pokt-network/sam/internal/models"
+)
+
+func TestTokenBucket_Wait(t *testing.T) {
+	b := newTokenBucket(50, 2)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := b.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Two tokens are available at once; the other three take 20ms each.
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("5 waits took %v, want at least ~60ms at 50/s with burst 2", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := newTokenBucket(0.001, 1).Wait(ctx); err != nil {
+		t.Errorf("first token should not wait: %v", err)
+	}
+	empty := newTokenBucket(0.001, 1)
+	empty.Wait(context.Background())
+	if err := empty.Wait(ctx); err == nil {
+		t.Error("expected error waiting on an empty bucket with a cancelled context")
+	}
+
+	if newTokenBucket(0, 10) != nil {
+		t.Error("a zero rate should be unlimited")
+	}
+}
+
+func TestForEach_BoundsConcurrency(t *testing.T) {
+	var active, peak, calls atomic.Int32
+	items := make([]int, 20)
+
+	ForEach(context.Background(), 3, items, func(_ context.Context, _ int, _ int) {
+		n := active.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		active.Add(-1)
+		calls.Add(1)
+	})
+
+	if calls.Load() != 20 {
+		t.Errorf("fn called %d times, want 20", calls.Load())
+	}
+	if peak.Load() > 3 {
+		t.Errorf("peak concurrency = %d, want at most 3", peak.Load())
+	}
+}
+
+func TestClient_NetworkConcurrencyLimit(t *testing.T) {
+	var active, peak atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := active.Add(1)
+		defer active.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		fmt.Fprint(w, `{"balances":[{"denom":"upokt","amount":"1"}]}`)
+	}))
+	defer srv.Close()
+
+	cfg := &config.Config{}
+	cfg.Config.Networks = map[string]config.NetworkConfig{
+		"pocket": {
+			APIEndpoint: srv.URL,
+			RPCEndpoint: srv.URL,
+			Limits:      config.LimitsConfig{RequestsPerSecond: 1000, Burst: 100, Concurrency: 2},
+		},
+	}
+	client := NewClient(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	client.Endpoints = NewEndpoints(cfg)
+
+	// More parallel callers than the network allows in flight.
+	ForEach(context.Background(), 10, make([]int, 10), func(ctx context.Context, _ int, _ int) {
+		if _, err := client.QueryBalance(ctx, testAddress, srv.URL, models.Denom); err != nil {
+			t.Errorf("QueryBalance() error = %v", err)
+		}
+	})
+
+	if peak.Load() > 2 {
+		t.Errorf("peak requests in flight = %d, want at most 2", peak.Load())
+	}
+}
+
+func TestLimitsFor(t *testing.T) {
+	if got := LimitsFor(config.NetworkConfig{}); got.RequestsPerSecond != DefaultRequestsPerSecond || got.Burst != DefaultBurst || got.Concurrency != DefaultConcurrency {
+		t.Errorf("default limits = %+v", got)
+	}
+	got := LimitsFor(config.NetworkConfig{Limits: config.LimitsConfig{RequestsPerSecond: 2.5, Concurrency: 4}})
+	if got.RequestsPerSecond != 2.5 || got.Burst != DefaultBurst || got.Concurrency != 4 {
+		t.Errorf("configured limits = %+v", got)
+	}
+}
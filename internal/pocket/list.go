@@ -0,0 +1,233 @@
+package pocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/pokt-network/sam/internal/config"
+	"github.com/pokt-network/sam/internal/models"
+)
+
+const (
+	// listPageSize is the page limit of list queries.
+	listPageSize = 200
+
+	// maxListPages stops a listing whose next key never runs out.
+	maxListPages = 100
+)
+
+// listAll fetches every page of the list query at path, following
+// pagination.next_key, and returns the entries of all pages. page picks the
+// entries and pagination out of one decoded page; what names the query in
+// logs and errors.
+func listAll[P, T any](ctx context.Context, c *Client, apiEndpoint, path string, query url.Values, what string, page func(*P) ([]T, models.APIPagination)) ([]T, error) {
+	var all []T
+	var key string
+	for n := 0; ; n++ {
+		if n == maxListPages {
+			return nil, fmt.Errorf("%s list exceeds %d pages", what, maxListPages)
+		}
+
+		q := maps.Clone(query)
+		if q == nil {
+			q = url.Values{}
+		}
+		q.Set("pagination.limit", strconv.Itoa(listPageSize))
+		if key != "" {
+			q.Set("pagination.key", key)
+		}
+		pagePath := path + "?" + q.Encode()
+		c.Logger.Debug("listing "+what, "endpoint", apiEndpoint, "path", pagePath)
+
+		resp, err := c.get(ctx, apiEndpoint, pagePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query %s API: %w", what, err)
+		}
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%s API returned status %d: %s", what, resp.StatusCode, string(body))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s response: %w", what, err)
+		}
+
+		var p P
+		if err := json.Unmarshal(body, &p); err != nil {
+			return nil, fmt.Errorf("failed to parse %s response: %w", what, err)
+		}
+		entries, pagination := page(&p)
+		all = append(all, entries...)
+
+		if pagination.NextKey == "" || pagination.NextKey == key {
+			return all, nil
+		}
+		key = pagination.NextKey
+	}
+}
+
+// ListApplications returns every application staked on the network, or only
+// those delegated to gateway when it is set. Liquid balances are not filled
+// in; see QueryBalances. Apps whose stake can't be parsed are left out.
+func (c *Client) ListApplications(ctx context.Context, apiEndpoint, network, gateway string) ([]*models.Application, error) {
+	query := url.Values{}
+	if gateway != "" {
+		query.Set("delegatee_gateway_address", gateway)
+	}
+
+	entries, err := listAll(ctx, c, apiEndpoint, "/pokt-network/poktroll/application/application", query, "applications",
+		func(p *models.APIApplicationsResponse) ([]models.APIApplication, models.APIPagination) {
+			return p.Applications, p.Pagination
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	apps := make([]*models.Application, 0, len(entries))
+	for _, entry := range entries {
+		app, err := c.applicationFromAPI(entry, network)
+		if err != nil {
+			c.Logger.Warn("skipping listed application", "address", entry.Address, "error", err)
+			continue
+		}
+		apps = append(apps, app)
+	}
+	c.Logger.Debug("listed applications", "endpoint", apiEndpoint, "gateway", gateway, "count", len(apps))
+	return apps, nil
+}
+
+// QueryBalances returns every coin held by each of addresses. The bank module
+// has no multi-account query, so they are fetched in parallel batches the
+// size of the network's concurrency limit. Addresses whose query failed are
+// missing from the map and listed in the error.
+func (c *Client) QueryBalances(ctx context.Context, addresses []string, apiEndpoint string) (map[string][]models.Coin, error) {
+	balances := make([][]models.Coin, len(addresses))
+	errs := make([]error, len(addresses))
+	for i := range errs {
+		errs[i] = errNotQueried
+	}
+
+	ForEach(ctx, c.Endpoints.concurrency(apiEndpoint), addresses, func(ctx context.Context, i int, address string) {
+		balances[i], errs[i] = c.QueryAllBalances(ctx, address, apiEndpoint)
+	})
+
+	result := make(map[string][]models.Coin, len(addresses))
+	var failed []error
+	for i, address := range addresses {
+		if errs[i] != nil {
+			failed = append(failed, fmt.Errorf("%s: %w", address, errs[i]))
+			continue
+		}
+		result[address] = balances[i]
+	}
+	return result, errors.Join(failed...)
+}
+
+// errNotQueried marks an item a cancelled fan-out never started.
+var errNotQueried = errors.New("not queried")
+
+// LoadApplications fetches the given applications of a network with list
+// queries instead of one query per address: a paged listing per configured
+// gateway, or of the whole network when there are none, joined against
+// addresses, then one batch of balances. Apps the listing missed, such as
+// ones delegated to another gateway, are queried one by one. The results
+// are in the order of addresses, and each has either an app or an error; a
+// listed app whose balance could not be loaded is an error, never an app
+// with a zero balance.
+// Apps carry the chain height read before the queries, so their data is at
+// least that recent.
+func LoadApplications(ctx context.Context, chain ChainReader, logger *slog.Logger, network string, netCfg config.NetworkConfig, addresses []string) ([]*models.Application, []error) {
+	apps := make([]*models.Application, len(addresses))
+	errs := make([]error, len(addresses))
+	if len(addresses) == 0 {
+		return apps, errs
+	}
+
+	var height int64
+	if block, err := chain.QueryLatestBlock(ctx, netCfg.APIEndpoint); err != nil {
+		logger.Warn("failed to query latest block", "network", network, "error", err)
+	} else {
+		height = block.Height
+	}
+
+	gateways := netCfg.Gateways
+	if len(gateways) == 0 {
+		gateways = []string{""}
+	}
+	listed := make(map[string]*models.Application)
+	for _, gateway := range gateways {
+		list, err := chain.ListApplications(ctx, netCfg.APIEndpoint, network, gateway)
+		if err != nil {
+			logger.Warn("failed to list applications, querying individually", "network", network, "gateway", gateway, "error", err)
+			continue
+		}
+		for _, app := range list {
+			listed[app.Address] = app
+		}
+	}
+
+	var found, missing []int
+	for i, address := range addresses {
+		if app, ok := listed[address]; ok {
+			copied := *app
+			apps[i] = &copied
+			found = append(found, i)
+		} else {
+			missing = append(missing, i)
+		}
+	}
+
+	if len(found) > 0 {
+		batch := make([]string, len(found))
+		for j, i := range found {
+			batch[j] = addresses[i]
+		}
+		balances, err := chain.QueryBalances(ctx, batch, netCfg.APIEndpoint)
+		if err != nil {
+			logger.Warn("failed to query balances", "network", network, "error", err)
+		}
+		denom := netCfg.BaseDenom()
+		for _, i := range found {
+			coins, ok := balances[addresses[i]]
+			if !ok {
+				apps[i], errs[i] = nil, errors.New("failed to query balance")
+				continue
+			}
+			apps[i].Denom = denom
+			apps[i].Balances = coins
+			if apps[i].LiquidBalance, err = balanceOf(coins, denom); err != nil {
+				apps[i], errs[i] = nil, fmt.Errorf("failed to parse balance: %w", err)
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		logger.Debug("querying applications missing from the list", "network", network, "count", len(missing))
+		for _, i := range missing {
+			errs[i] = errNotQueried
+		}
+		ForEach(ctx, LimitsFor(netCfg).Concurrency, missing, func(ctx context.Context, _ int, i int) {
+			apps[i], errs[i] = chain.QueryApplication(ctx, addresses[i], netCfg.APIEndpoint, network, netCfg.BaseDenom())
+		})
+		for _, i := range missing {
+			if errors.Is(errs[i], errNotQueried) && ctx.Err() != nil {
+				errs[i] = fmt.Errorf("not queried: %w", ctx.Err())
+			}
+		}
+	}
+
+	for _, app := range apps {
+		if app != nil {
+			app.Height = height
+		}
+	}
+	return apps, errs
+}
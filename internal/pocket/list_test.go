@@ -0,0 +1,276 @@
+package pocket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pokt-network/sam/internal/config"
+	"github.com/pokt-network/sam/internal/models"
+)
+
+// listServer serves n applications, delegated alternately to gateways "gwA"
+// and "gwB", two per page, plus per-address application and balance
+// queries. It counts the requests to each. Balance queries of failBalance
+// fail.
+type listServer struct {
+	*httptest.Server
+	lists, single, balances atomic.Int32
+	failBalance             atomic.Value // string
+}
+
+func newListServer(t *testing.T, n int) *listServer {
+	t.Helper()
+	s := &listServer{}
+	addr := func(i int) string { return fmt.Sprintf("pokt1app%034d", i) }
+	gateway := func(i int) string { return []string{"gwA", "gwB"}[i%2] }
+	app := func(i int) string {
+		return fmt.Sprintf(`{"address":%q,"stake":{"denom":"upokt","amount":"%d"},"service_configs":[{"service_id":"anvil"}],"delegatee_gateway_addresses":[%q]}`,
+			addr(i), 1000+i, gateway(i))
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pokt-network/poktroll/application/application", func(w http.ResponseWriter, r *http.Request) {
+		s.lists.Add(1)
+		q := r.URL.Query()
+		if q.Get("pagination.limit") == "" {
+			t.Error("list query without pagination.limit")
+		}
+		var matched []int
+		for i := 0; i < n; i++ {
+			if gw := q.Get("delegatee_gateway_address"); gw == "" || gw == gateway(i) {
+				matched = append(matched, i)
+			}
+		}
+		offset := 0
+		fmt.Sscanf(q.Get("pagination.key"), "page%d", &offset)
+		end := min(offset+2, len(matched))
+
+		entries := make([]string, 0, 2)
+		for _, i := range matched[offset:end] {
+			entries = append(entries, app(i))
+		}
+		next := "null"
+		if end < len(matched) {
+			next = fmt.Sprintf(`"page%d"`, end)
+		}
+		fmt.Fprintf(w, `{"applications":[%s],"pagination":{"next_key":%s,"total":"%d"}}`, strings.Join(entries, ","), next, len(matched))
+	})
+	mux.HandleFunc("/pokt-network/poktroll/application/application/", func(w http.ResponseWriter, r *http.Request) {
+		s.single.Add(1)
+		var i int
+		fmt.Sscanf(strings.TrimPrefix(r.URL.Path, "/pokt-network/poktroll/application/application/pokt1app"), "%d", &i)
+		if i >= n {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `{"application":%s}`, app(i))
+	})
+	mux.HandleFunc("/cosmos/bank/v1beta1/balances/", func(w http.ResponseWriter, r *http.Request) {
+		s.balances.Add(1)
+		if fail, _ := s.failBalance.Load().(string); fail != "" && strings.HasSuffix(r.URL.Path, "/"+fail) {
+			http.Error(w, "balance unavailable", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `{"balances":[{"denom":"upokt","amount":"7"},{"denom":"uusdc","amount":"3"}]}`)
+	})
+
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestClient_ListApplications(t *testing.T) {
+	srv := newListServer(t, 5)
+	client := newEndpointsClient([]string{srv.URL}, []string{srv.URL})
+
+	apps, err := client.ListApplications(context.Background(), srv.URL, "pocket", "")
+	if err != nil {
+		t.Fatalf("ListApplications() error = %v", err)
+	}
+	if len(apps) != 5 {
+		t.Fatalf("got %d apps, want 5", len(apps))
+	}
+	if got := srv.lists.Load(); got != 3 {
+		t.Errorf("got %d list requests for 5 apps at 2 per page, want 3", got)
+	}
+	if apps[4].Stake != 1004 || apps[4].ServiceID != "anvil" || apps[4].Gateway != "gwA" || apps[4].Network != "pocket" {
+		t.Errorf("apps[4] = %+v", apps[4])
+	}
+
+	apps, err = client.ListApplications(context.Background(), srv.URL, "pocket", "gwB")
+	if err != nil || len(apps) != 2 {
+		t.Errorf("ListApplications(gwB) = %d apps, %v; want 2", len(apps), err)
+	}
+}
+
+func TestClient_QueryBalances(t *testing.T) {
+	srv := newListServer(t, 0)
+	client := newEndpointsClient([]string{srv.URL}, []string{srv.URL})
+
+	addresses := []string{"pokt1a", "pokt1b", "pokt1c"}
+	balances, err := client.QueryBalances(context.Background(), addresses, srv.URL)
+	if err != nil {
+		t.Fatalf("QueryBalances() error = %v", err)
+	}
+	for _, a := range addresses {
+		if got := balances[a]; len(got) != 2 || got[0].Amount != "7" || got[1].Denom != "uusdc" {
+			t.Errorf("balances of %s = %+v, want 7upokt and 3uusdc", a, got)
+		}
+	}
+}
+
+func TestClient_QueryBankAccount_ConfiguredDenom(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("pagination.key") {
+		case "":
+			fmt.Fprint(w, `{"balances":[{"denom":"upokt","amount":"5"}],"pagination":{"next_key":"Ag=="}}`)
+		case "Ag==":
+			fmt.Fprint(w, `{"balances":[{"denom":"ustake","amount":"9"}],"pagination":{"next_key":null}}`)
+		default:
+			http.Error(w, "bad key", http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	client := newEndpointsClient([]string{srv.URL}, []string{srv.URL})
+
+	bank, err := client.QueryBankAccount(context.Background(), "pokt1bank", srv.URL, "pocket", "ustake")
+	if err != nil {
+		t.Fatalf("QueryBankAccount() error = %v", err)
+	}
+	if bank.Balance != 9 || bank.Denom != "ustake" || len(bank.Balances) != 2 {
+		t.Errorf("bank = %+v, want 9ustake of both pages' balances", bank)
+	}
+
+	// The same endpoint read in another denom.
+	if balance, err := client.QueryBalance(context.Background(), "pokt1bank", srv.URL, models.Denom); err != nil || balance != 5 {
+		t.Errorf("QueryBalance() = %d, %v; want 5", balance, err)
+	}
+}
+
+func TestLoadApplications(t *testing.T) {
+	srv := newListServer(t, 6)
+	client := newEndpointsClient([]string{srv.URL}, []string{srv.URL})
+	netCfg := config.NetworkConfig{APIEndpoint: srv.URL, Gateways: []string{"gwA"}}
+
+	// App 1 is delegated to gwB, so the gwA listing misses it; app 9 doesn't
+	// exist.
+	addresses := []string{"pokt1app0000000000000000000000000000000004", "pokt1app0000000000000000000000000000000001",
+		"pokt1app0000000000000000000000000000000000", "pokt1app0000000000000000000000000000000009"}
+	apps, errs := LoadApplications(context.Background(), client, client.Logger, "pocket", netCfg, addresses)
+
+	for i, want := range []int64{1004, 1001, 1000} {
+		if errs[i] != nil {
+			t.Errorf("app %d error = %v", i, errs[i])
+			continue
+		}
+		if apps[i].Address != addresses[i] || apps[i].Stake != want || apps[i].LiquidBalance != 7 || len(apps[i].Balances) != 2 {
+			t.Errorf("apps[%d] = %+v", i, apps[i])
+		}
+	}
+	if errs[3] == nil || !strings.Contains(errs[3].Error(), "not found") {
+		t.Errorf("missing app error = %v, want not found", errs[3])
+	}
+
+	if got := srv.lists.Load(); got != 2 {
+		t.Errorf("got %d list requests, want 2 (3 gwA apps, 2 per page)", got)
+	}
+	if got := srv.single.Load(); got != 2 {
+		t.Errorf("got %d single application queries, want 2 for the apps not listed", got)
+	}
+}
+
+func TestLoadApplications_BalanceFailure(t *testing.T) {
+	srv := newListServer(t, 2)
+	client := newEndpointsClient([]string{srv.URL}, []string{srv.URL})
+	netCfg := config.NetworkConfig{APIEndpoint: srv.URL}
+	addresses := []string{"pokt1app0000000000000000000000000000000000", "pokt1app0000000000000000000000000000000001"}
+	srv.failBalance.Store(addresses[1])
+
+	// A listed app without its balance is an error, not an empty account.
+	apps, errs := LoadApplications(context.Background(), client, client.Logger, "pocket", netCfg, addresses)
+	if errs[0] != nil || apps[0] == nil || apps[0].LiquidBalance != 7 {
+		t.Errorf("app 0 = %+v, %v; want balance 7", apps[0], errs[0])
+	}
+	if errs[1] == nil || apps[1] != nil {
+		t.Errorf("app 1 = %+v, %v; want a balance error", apps[1], errs[1])
+	}
+
+	if app, err := client.QueryApplication(context.Background(), addresses[1], srv.URL, "pocket", models.Denom); err == nil {
+		t.Errorf("QueryApplication() = %+v, want a balance error", app)
+	}
+}
+
+func TestClient_ListGrants(t *testing.T) {
+	const bank = "pokt1bank"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		second := r.URL.Query().Get("pagination.key") == "next"
+		switch r.URL.Path {
+		case "/cosmos/authz/v1beta1/grants/grantee/" + bank:
+			if second {
+				fmt.Fprintf(w, `{"grants":[{"granter":"pokt1app2","grantee":%q,"authorization":{"@type":"/cosmos.authz.v1beta1.GenericAuthorization","msg":%q},"expiration":"2000-01-01T00:00:00Z"}],"pagination":{}}`, bank, MsgStakeApplicationType)
+				return
+			}
+			// A grant for another message is not a stake grant.
+			fmt.Fprintf(w, `{"grants":[{"granter":"pokt1app1","grantee":%q,"authorization":{"@type":"/cosmos.authz.v1beta1.GenericAuthorization","msg":%q}},{"granter":"pokt1app3","grantee":%q,"authorization":{"@type":"/cosmos.authz.v1beta1.GenericAuthorization","msg":"/cosmos.bank.v1beta1.MsgSend"}}],"pagination":{"next_key":"next"}}`, bank, MsgStakeApplicationType, bank)
+		case "/cosmos/feegrant/v1beta1/issued/" + bank:
+			fmt.Fprintf(w, `{"allowances":[{"granter":%q,"grantee":"pokt1app1","allowance":{"@type":"/cosmos.feegrant.v1beta1.AllowedMsgAllowance","allowance":{"@type":"/cosmos.feegrant.v1beta1.BasicAllowance","spend_limit":[{"denom":"upokt","amount":"500"}]}}},{"granter":%q,"grantee":"pokt1app2","allowance":{"@type":"/cosmos.feegrant.v1beta1.BasicAllowance","spend_limit":[]}}],"pagination":{}}`, bank, bank)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	client := newEndpointsClient([]string{srv.URL}, []string{srv.URL})
+
+	grants, err := client.ListStakeGrants(context.Background(), bank, srv.URL)
+	if err != nil {
+		t.Fatalf("ListStakeGrants() error = %v", err)
+	}
+	if len(grants) != 2 || !grants["pokt1app1"].Active || grants["pokt1app2"].Active || grants["pokt1app2"].Grantee != bank {
+		t.Errorf("ListStakeGrants() = %+v, want an active grant from app1 and an expired one from app2", grants)
+	}
+
+	allowances, err := client.ListFeeAllowances(context.Background(), bank, srv.URL, models.Denom)
+	if err != nil {
+		t.Fatalf("ListFeeAllowances() error = %v", err)
+	}
+	if a := allowances["pokt1app1"]; a == nil || a.SpendLimit == nil || *a.SpendLimit != 500 || a.Granter != bank {
+		t.Errorf("app1 allowance = %+v, want a 500 spend limit", a)
+	}
+	if a := allowances["pokt1app2"]; a == nil || a.SpendLimit != nil || !a.Active {
+		t.Errorf("app2 allowance = %+v, want an unlimited active allowance", a)
+	}
+}
+
+func TestClient_QueryServices_Paginates(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Query().Get("pagination.key") {
+		case "":
+			fmt.Fprint(w, `{"service":[{"id":"anvil","name":"Anvil"},{"id":"base","name":"Base"}],"pagination":{"next_key":"AgE=","total":"3"}}`)
+		case "AgE=":
+			fmt.Fprint(w, `{"service":[{"id":"eth","name":"Ethereum"}],"pagination":{"next_key":null,"total":"3"}}`)
+		default:
+			http.Error(w, "bad key", http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+	client := newEndpointsClient([]string{srv.URL}, []string{srv.URL})
+
+	services, err := client.QueryServices(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("QueryServices() error = %v", err)
+	}
+	if len(services) != 3 || services[2].ID != "eth" {
+		t.Errorf("services = %+v, want all 3 pages' services", services)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("got %d requests, want 2", got)
+	}
+}
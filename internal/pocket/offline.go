@@ -0,0 +1,203 @@
+package pocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pokt-network/sam/internal/config"
+	"github.com/pokt-network/sam/internal/models"
+	"github.com/pokt-network/sam/internal/validate"
+)
+
+// generateOnlyGas is the gas limit for unsigned transactions. --gas=auto needs
+// to simulate with the signer's key, which an offline bank doesn't provide.
+const generateOnlyGas = "200000"
+
+// generateFund builds an unsigned bank→app send and records it as a pending
+// signature request.
+func (e *Executor) generateFund(ctx context.Context, appAddress, bankAddress, network string, amount int64, rpcEndpoint string, netCfg config.NetworkConfig) (*models.TransactionResponse, error) {
+	amountStr := e.coins(network, amount)
+
+	e.Logger.Info("generating unsigned fund transaction", "address", appAddress, "amount", amountStr)
+
+	args := []string{
+		"tx", "bank", "send",
+		bankAddress,
+		appAddress,
+		amountStr,
+		"--node", rpcEndpoint,
+		"--chain-id", network,
+		"--generate-only",
+		"--gas", generateOnlyGas,
+		e.feesFlag(network),
+		"--output", "json",
+	}
+
+	tx := models.PendingTx{
+		Network: network,
+		Kind:    "fund",
+		From:    bankAddress,
+		To:      appAddress,
+		Amount:  amount,
+	}
+	return e.generatePending(ctx, args, tx, netCfg)
+}
+
+// generatePending runs a --generate-only pocketd command for a bank
+// transaction and records its output as a pending signature request of
+// tx.Kind. For a multisig bank the request also lists the signers and the
+// number of signatures required.
+func (e *Executor) generatePending(ctx context.Context, args []string, tx models.PendingTx, netCfg config.NetworkConfig) (*models.TransactionResponse, error) {
+	if e.Pending == nil {
+		return nil, fmt.Errorf("network %s has an offline bank but no pending transaction store is configured", tx.Network)
+	}
+
+	e.Logger.Debug("generate "+tx.Kind+" command", "args", args)
+
+	output, err := e.Run(ctx, args...)
+	if err != nil {
+		e.Logger.Error("generate "+tx.Kind+" command failed", "error", err)
+		return &models.TransactionResponse{
+			Success: false,
+			Message: "failed to generate unsigned " + tx.Kind + " transaction",
+		}, nil
+	}
+
+	if !json.Valid([]byte(output)) {
+		return nil, fmt.Errorf("pocketd returned a non-JSON unsigned transaction")
+	}
+
+	tx.UnsignedTx = output
+	if netCfg.MultisigBank() {
+		tx.Threshold = netCfg.Multisig.Threshold
+		tx.Signers = netCfg.Multisig.Signers
+	}
+
+	pending, err := e.Pending.Add(tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store pending transaction: %w", err)
+	}
+
+	e.Logger.Info("unsigned "+tx.Kind+" transaction awaiting signature", "pending_id", pending.ID, "address", tx.To)
+
+	return &models.TransactionResponse{
+		Success:   true,
+		Message:   "Unsigned transaction generated; awaiting offline bank signature",
+		PendingID: pending.ID,
+	}, nil
+}
+
+// BroadcastSigned broadcasts a signed transaction (Cosmos SDK JSON) and
+// returns its hash.
+func (e *Executor) BroadcastSigned(ctx context.Context, signedTx []byte, network, rpcEndpoint string) (*models.TransactionResponse, error) {
+	tempFile, err := os.CreateTemp("", "pocketd-signed-*.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp tx file: %w", err)
+	}
+	if err := tempFile.Chmod(0600); err != nil {
+		tempFile.Close()
+		os.Remove(tempFile.Name())
+		return nil, fmt.Errorf("failed to set temp file permissions: %w", err)
+	}
+	tempTx := tempFile.Name()
+
+	if _, err := tempFile.Write(signedTx); err != nil {
+		tempFile.Close()
+		os.Remove(tempTx)
+		return nil, fmt.Errorf("failed to write temp tx file: %w", err)
+	}
+	if err := tempFile.Close(); err != nil {
+		os.Remove(tempTx)
+		return nil, fmt.Errorf("failed to close temp tx file: %w", err)
+	}
+	defer os.Remove(tempTx)
+
+	args := []string{
+		"tx", "broadcast", tempTx,
+		"--node", rpcEndpoint,
+		"--chain-id", network,
+		"--output", "json",
+	}
+
+	e.Logger.Debug("broadcast command", "args", args)
+
+	output, err := e.Run(ctx, args...)
+	if err != nil {
+		e.Logger.Error("broadcast command failed", "error", err)
+		return &models.TransactionResponse{
+			Success: false,
+			Message: "broadcast failed",
+		}, nil
+	}
+
+	e.Logger.Info("signed transaction broadcast", "output", output)
+
+	if txhash := parseTxHash(output); txhash != "" {
+		return &models.TransactionResponse{TxHash: txhash, Success: true}, nil
+	}
+
+	return &models.TransactionResponse{Success: true, Message: "Transaction submitted"}, nil
+}
+
+// Multisign combines the partial signatures collected for a multisig pending
+// transaction into a signed transaction (pocketd tx multisign) and returns it.
+func (e *Executor) Multisign(ctx context.Context, tx models.PendingTx, keyName, rpcEndpoint string) ([]byte, error) {
+	if err := validate.KeyName(keyName); err != nil {
+		return nil, fmt.Errorf("invalid multisig key: %w", err)
+	}
+	if len(tx.Signatures) < tx.Threshold {
+		return nil, fmt.Errorf("have %d of %d required signatures", len(tx.Signatures), tx.Threshold)
+	}
+
+	dir, err := os.MkdirTemp("", "pocketd-multisign-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	unsignedPath := filepath.Join(dir, "unsigned.json")
+	if err := os.WriteFile(unsignedPath, []byte(tx.UnsignedTx), 0600); err != nil {
+		return nil, fmt.Errorf("failed to write unsigned tx: %w", err)
+	}
+
+	args := []string{"tx", "multisign", unsignedPath, keyName}
+
+	// Sign in configured signer order so the output is deterministic.
+	for i, signer := range tx.Signers {
+		sig, ok := tx.Signatures[signer]
+		if !ok {
+			continue
+		}
+		sigPath := filepath.Join(dir, fmt.Sprintf("sig-%d.json", i))
+		if err := os.WriteFile(sigPath, []byte(sig), 0600); err != nil {
+			return nil, fmt.Errorf("failed to write signature: %w", err)
+		}
+		args = append(args, sigPath)
+	}
+
+	args = append(args,
+		"--node", rpcEndpoint,
+		"--chain-id", tx.Network,
+	)
+
+	if e.Config.Config.KeyringBackend != "" {
+		args = append(args, "--keyring-backend", e.Config.Config.KeyringBackend)
+	}
+
+	e.Logger.Debug("multisign command", "args", args)
+
+	output, err := e.Run(ctx, args...)
+	if err != nil {
+		e.Logger.Error("multisign command failed", "error", err)
+		return nil, fmt.Errorf("failed to combine multisig signatures")
+	}
+
+	if !json.Valid([]byte(output)) {
+		return nil, fmt.Errorf("pocketd returned a non-JSON multisig transaction")
+	}
+
+	return []byte(output), nil
+}
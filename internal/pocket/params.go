@@ -0,0 +1,130 @@
+package pocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pokt-network/sam/internal/config"
+	"github.com/pokt-network/sam/internal/models"
+)
+
+// getJSON queries path and decodes its JSON body into out; what names the
+// query in errors.
+func (c *Client) getJSON(ctx context.Context, apiEndpoint, path, what string, out any) error {
+	c.Logger.Debug("querying "+what, "endpoint", apiEndpoint, "path", path)
+
+	resp, err := c.get(ctx, apiEndpoint, path)
+	if err != nil {
+		return fmt.Errorf("failed to query %s API: %w", what, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s API returned status %d: %s", what, resp.StatusCode, string(body))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s response: %w", what, err)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to parse %s response: %w", what, err)
+	}
+	return nil
+}
+
+// QueryApplicationParams returns the application module's parameters.
+func (c *Client) QueryApplicationParams(ctx context.Context, apiEndpoint string) (*models.ApplicationParams, error) {
+	var resp models.APIApplicationParamsResponse
+	if err := c.getJSON(ctx, apiEndpoint, "/pokt-network/poktroll/application/params", "application params", &resp); err != nil {
+		return nil, err
+	}
+
+	params := &models.ApplicationParams{}
+	if resp.Params.MinStake != nil {
+		minStake, err := resp.Params.MinStake.Upokt()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse min_stake: %w", err)
+		}
+		params.MinStake = minStake
+	}
+	maxGateways, err := parseHeight(resp.Params.MaxDelegatedGateways)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse max_delegated_gateways: %w", err)
+	}
+	params.MaxDelegatedGateways = maxGateways
+	return params, nil
+}
+
+// QuerySharedParams returns the shared module's session parameters.
+func (c *Client) QuerySharedParams(ctx context.Context, apiEndpoint string) (*models.SharedParams, error) {
+	var resp models.APISharedParamsResponse
+	if err := c.getJSON(ctx, apiEndpoint, "/pokt-network/poktroll/shared/params", "shared params", &resp); err != nil {
+		return nil, err
+	}
+
+	p := resp.Params
+	params := &models.SharedParams{}
+	for _, f := range []struct {
+		name string
+		raw  string
+		dst  *int64
+	}{
+		{"num_blocks_per_session", p.NumBlocksPerSession, &params.NumBlocksPerSession},
+		{"grace_period_end_offset_blocks", p.GracePeriodEndOffsetBlocks, &params.GracePeriodEndOffsetBlocks},
+		{"claim_window_open_offset_blocks", p.ClaimWindowOpenOffsetBlocks, &params.ClaimWindowOpenOffsetBlocks},
+		{"claim_window_close_offset_blocks", p.ClaimWindowCloseOffsetBlocks, &params.ClaimWindowCloseOffsetBlocks},
+		{"proof_window_open_offset_blocks", p.ProofWindowOpenOffsetBlocks, &params.ProofWindowOpenOffsetBlocks},
+		{"proof_window_close_offset_blocks", p.ProofWindowCloseOffsetBlocks, &params.ProofWindowCloseOffsetBlocks},
+		{"supplier_unbonding_period_sessions", p.SupplierUnbondingPeriodSessions, &params.SupplierUnbondingPeriodSessions},
+		{"application_unbonding_period_sessions", p.ApplicationUnbondingPeriodSessions, &params.ApplicationUnbondingPeriodSessions},
+		{"compute_units_to_tokens_multiplier", p.ComputeUnitsToTokensMultiplier, &params.ComputeUnitsToTokensMultiplier},
+	} {
+		if f.raw == "" {
+			continue
+		}
+		v, err := strconv.ParseInt(f.raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", f.name, err)
+		}
+		*f.dst = v
+	}
+	return params, nil
+}
+
+// QueryTokenomicsParams returns the tokenomics module's parameters.
+func (c *Client) QueryTokenomicsParams(ctx context.Context, apiEndpoint string) (*models.TokenomicsParams, error) {
+	var resp models.APITokenomicsParamsResponse
+	if err := c.getJSON(ctx, apiEndpoint, "/pokt-network/poktroll/tokenomics/params", "tokenomics params", &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Params, nil
+}
+
+// QueryChainParams fetches the application, shared and tokenomics params of
+// a network.
+func QueryChainParams(ctx context.Context, chain ChainReader, network string, netCfg config.NetworkConfig) (*models.ChainParams, error) {
+	app, err := chain.QueryApplicationParams(ctx, netCfg.APIEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	shared, err := chain.QuerySharedParams(ctx, netCfg.APIEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	tokenomics, err := chain.QueryTokenomicsParams(ctx, netCfg.APIEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ChainParams{
+		Network:     network,
+		Application: *app,
+		Shared:      *shared,
+		Tokenomics:  *tokenomics,
+		FetchedAt:   time.Now(),
+	}, nil
+}
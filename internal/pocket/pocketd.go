@@ -1,37 +1,77 @@
 package pocket
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log/slog"
 	"os"
 	"os/exec"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pokt-network/sam/internal/config"
+	"github.com/pokt-network/sam/internal/models"
 	"github.com/pokt-network/sam/internal/validate"
 )
 
+// DefaultCommandTimeout bounds a pocketd command when pocketd-timeout is unset.
+const DefaultCommandTimeout = 2 * time.Minute
+
+// PendingStore records unsigned transactions generated for an offline bank.
+type PendingStore interface {
+	Add(tx models.PendingTx) (models.PendingTx, error)
+}
+
 // Executor runs pocketd CLI commands for write transactions.
 type Executor struct {
-	Binary string
-	Config *config.Config
-	Client *Client
-	Logger *slog.Logger
+	Binary  string
+	Config  *config.Config
+	Client  ChainReader
+	Pending PendingStore
+	Signers map[string]Signer
+	Timeout time.Duration // per command; 0 means no limit beyond ctx
+	Logger  *slog.Logger
+
+	// Endpoints, when set, replaces a network's --node with its healthiest
+	// RPC endpoint.
+	Endpoints *Endpoints
+
+	keyring keyringState
 }
 
-// NewExecutor returns an Executor that shells out to pocketd.
-func NewExecutor(cfg *config.Config, client *Client, logger *slog.Logger) *Executor {
-	return &Executor{
-		Binary: "pocketd",
-		Config: cfg,
-		Client: client,
-		Logger: logger,
+// NewExecutor returns an Executor that shells out to pocketd. Keys whose
+// configured signer isn't the local keyring are signed through Signers.
+func NewExecutor(cfg *config.Config, client ChainReader, pending PendingStore, logger *slog.Logger) *Executor {
+	e := &Executor{
+		Binary:  "pocketd",
+		Config:  cfg,
+		Client:  client,
+		Pending: pending,
+		Timeout: DefaultCommandTimeout,
+		Logger:  logger,
 	}
+	if cfg.Config.PocketdTimeout > 0 {
+		e.Timeout = cfg.Config.PocketdTimeout
+	}
+	e.Signers = newSigners(e)
+	return e
 }
 
-// Run executes a pocketd command with the given arguments.
-func (e *Executor) Run(args ...string) (string, error) {
-	cmd := exec.Command(e.Binary, args...)
+// Run executes a pocketd command with the given arguments. The command is
+// killed when ctx is cancelled or Timeout elapses. A configured keyring
+// passphrase is written to the command's stdin, never to argv.
+func (e *Executor) Run(ctx context.Context, args ...string) (string, error) {
+	if e.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.Timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, e.Binary, e.selectNode(args)...)
+	// Don't wait forever on output pipes held open by a killed pocketd's children.
+	cmd.WaitDelay = 5 * time.Second
 
 	cmd.Env = []string{
 		"HOME=" + os.Getenv("HOME"),
@@ -49,7 +89,18 @@ func (e *Executor) Run(args ...string) (string, error) {
 		cmd.Env = append(cmd.Env, "KEYRING_BACKEND="+e.Config.Config.KeyringBackend)
 	}
 
+	pass, err := e.passphrase()
+	if err != nil {
+		return "", err
+	}
+	if pass != "" {
+		cmd.Stdin = strings.NewReader(pass + "\n")
+	}
+
 	output, err := cmd.CombinedOutput()
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return "", fmt.Errorf("pocketd command aborted: %w", ctxErr)
+	}
 	if err != nil {
 		return "", fmt.Errorf("pocketd command failed: %s - %w", string(output), err)
 	}
@@ -67,3 +118,73 @@ func parseTxHash(output string) string {
 	}
 	return ""
 }
+
+// selectNode returns args with the --node value swapped for the healthiest
+// RPC endpoint of its network.
+func (e *Executor) selectNode(args []string) []string {
+	if e.Endpoints == nil {
+		return args
+	}
+	for i := 0; i < len(args)-1; i++ {
+		if args[i] != "--node" {
+			continue
+		}
+		node := e.Endpoints.Pick(EndpointRPC, args[i+1])
+		if node == args[i+1] {
+			return args
+		}
+		e.Logger.Warn("using fallback RPC endpoint", "primary", args[i+1], "node", node)
+		out := append([]string(nil), args...)
+		out[i+1] = node
+		return out
+	}
+	return args
+}
+
+// coins formats an amount in the network's base denom as pocketd expects
+// it, such as "5000000upokt".
+func (e *Executor) coins(network string, amount int64) string {
+	return strconv.FormatInt(amount, 10) + e.Config.Config.Networks[network].BaseDenom()
+}
+
+// feesFlag is the --fees flag paying TxFee in the network's base denom.
+func (e *Executor) feesFlag(network string) string {
+	return "--fees=" + e.coins(network, TxFee)
+}
+
+// runTx runs a signing pocketd command and maps its output to a
+// TransactionResponse, logging under the given operation name. Transactions
+// whose signer (from) is not held in the local keyring are generated unsigned,
+// signed by the configured Signer and broadcast instead. A keyring-signed
+// transaction updates KeyringStatus: success proves the keyring unlocked, and
+// a failure re-checks it.
+func (e *Executor) runTx(ctx context.Context, op, from string, args []string) (*models.TransactionResponse, error) {
+	if name, ok := e.externalSigner(from, args); ok {
+		return e.signAndBroadcast(ctx, op, from, name, args)
+	}
+
+	e.Logger.Debug(op+" command", "args", args)
+
+	output, err := e.Run(ctx, args...)
+	if err != nil {
+		e.Logger.Error(op+" command failed", "error", err)
+		if ctx.Err() == nil {
+			if err := e.CheckKeyring(ctx); err != nil {
+				e.Logger.Error("keyring check failed after "+op+" command", "error", err)
+			}
+		}
+		return &models.TransactionResponse{
+			Success: false,
+			Message: op + " transaction failed",
+		}, nil
+	}
+
+	e.setKeyringStatus(true)
+	e.Logger.Info(op+" transaction submitted", "output", output)
+
+	if txhash := parseTxHash(output); txhash != "" {
+		return &models.TransactionResponse{TxHash: txhash, Success: true}, nil
+	}
+
+	return &models.TransactionResponse{Success: true, Message: "Transaction submitted"}, nil
+}
@@ -0,0 +1,81 @@
+package pocket
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pokt-network/sam/internal/config"
+)
+
+// hangingPocketd writes a script that never finishes on its own.
+func hangingPocketd(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "pocketd")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\nexec sleep 30\n"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRun_Timeout(t *testing.T) {
+	e := newKeyringTestExecutor(t, &config.Config{})
+	e.Binary = hangingPocketd(t)
+	e.Timeout = 100 * time.Millisecond
+
+	start := time.Now()
+	_, err := e.Run(context.Background(), "status")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Run() error = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Run() took %v after timeout", elapsed)
+	}
+}
+
+func TestRun_Cancelled(t *testing.T) {
+	e := newKeyringTestExecutor(t, &config.Config{})
+	e.Binary = hangingPocketd(t)
+	e.Timeout = 0
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	if _, err := e.Run(ctx, "status"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run() error = %v, want context canceled", err)
+	}
+}
+
+func TestNewExecutor_Timeout(t *testing.T) {
+	cfg := &config.Config{}
+	if e := newKeyringTestExecutor(t, cfg); e.Timeout != DefaultCommandTimeout {
+		t.Errorf("default Timeout = %v, want %v", e.Timeout, DefaultCommandTimeout)
+	}
+
+	cfg.Config.PocketdTimeout = 30 * time.Second
+	if e := newKeyringTestExecutor(t, cfg); e.Timeout != 30*time.Second {
+		t.Errorf("configured Timeout = %v, want 30s", e.Timeout)
+	}
+}
+
+func TestExecutor_Coins(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Config.Networks = map[string]config.NetworkConfig{
+		"pocket": {},
+		"local":  {Denom: "ustake"},
+	}
+	e := newKeyringTestExecutor(t, cfg)
+
+	if got := e.coins("pocket", 5_000_000); got != "5000000upokt" {
+		t.Errorf("coins(pocket) = %q, want 5000000upokt", got)
+	}
+	if got := e.coins("local", 42); got != "42ustake" {
+		t.Errorf("coins(local) = %q, want 42ustake", got)
+	}
+	if got := e.feesFlag("local"); got != "--fees=1ustake" {
+		t.Errorf("feesFlag(local) = %q, want --fees=1ustake", got)
+	}
+}
@@ -0,0 +1,120 @@
+package pocket
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/pokt-network/sam/internal/config"
+)
+
+// Retry and circuit breaker defaults.
+const (
+	DefaultRetryAttempts   = 3
+	DefaultInitialBackoff  = 200 * time.Millisecond
+	DefaultMaxBackoff      = 2 * time.Second
+	DefaultBreakerFailures = 5
+	DefaultBreakerCooldown = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned without a request being made when every
+// endpoint for a query has an open circuit.
+var ErrCircuitOpen = errors.New("circuit open: endpoint is failing")
+
+// RetryPolicy controls how idempotent queries are retried.
+type RetryPolicy struct {
+	Attempts       int           // total tries per query, including the first
+	InitialBackoff time.Duration // wait before the first retry
+	MaxBackoff     time.Duration // cap on the doubling wait
+}
+
+// DefaultRetryPolicy is used by NewClient.
+var DefaultRetryPolicy = RetryPolicy{
+	Attempts:       DefaultRetryAttempts,
+	InitialBackoff: DefaultInitialBackoff,
+	MaxBackoff:     DefaultMaxBackoff,
+}
+
+// RetryPolicyFromConfig returns the configured retry policy, falling back to
+// the defaults for unset fields.
+func RetryPolicyFromConfig(cfg *config.Config) RetryPolicy {
+	p := DefaultRetryPolicy
+	r := cfg.Config.QueryRetry
+	if r.Attempts > 0 {
+		p.Attempts = r.Attempts
+	}
+	if r.InitialBackoff > 0 {
+		p.InitialBackoff = r.InitialBackoff
+	}
+	if r.MaxBackoff > 0 {
+		p.MaxBackoff = r.MaxBackoff
+	}
+	return p
+}
+
+// backoff returns the wait before retry n (1-based): the initial backoff
+// doubled per retry, capped, with jitter in [d/2, d) so concurrent queries
+// don't retry in lockstep.
+func (p RetryPolicy) backoff(n int) time.Duration {
+	d := p.InitialBackoff
+	for i := 1; i < n && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	d = min(d, p.MaxBackoff)
+	if d <= 1 {
+		return d
+	}
+	return d/2 + rand.N(d/2)
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// retryableStatus reports whether a response status is worth retrying:
+// timeouts, rate limiting and gateway/availability errors (502, 503, 504).
+// Other statuses, including a 500 from a node that failed the query itself,
+// are answers and are returned to the caller.
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests,
+		http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryableError reports whether a transport error is worth retrying:
+// network failures (refused, reset, DNS, timeouts) and truncated responses.
+// Errors such as a blocked redirect are not.
+func retryableError(err error) bool {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+
+	var netErr net.Error
+	switch {
+	case errors.As(err, &netErr):
+		return true
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return true
+	case errors.Is(err, syscall.ECONNREFUSED), errors.Is(err, syscall.ECONNRESET):
+		return true
+	}
+	return false
+}
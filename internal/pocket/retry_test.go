@@ -0,0 +1,257 @@
+package pocket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pokt-network/sam/internal/models"
+)
+
+const testAddress = "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+
+// flakyServer fails the first failures requests with status, then answers
+// balance queries. It counts every request it receives.
+func flakyServer(t *testing.T, failures int32, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		fmt.Fprint(w, `{"balances":[{"denom":"upokt","amount":"42"}]}`)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func newRetryClient() *Client {
+	client := NewClient(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	client.Retry = RetryPolicy{Attempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 4 * time.Millisecond}
+	return client
+}
+
+func TestClient_RetriesTransientStatus(t *testing.T) {
+	srv, requests := flakyServer(t, 2, http.StatusBadGateway)
+	client := newRetryClient()
+
+	balance, err := client.QueryBalance(context.Background(), testAddress, srv.URL, models.Denom)
+	if err != nil {
+		t.Fatalf("QueryBalance() error = %v", err)
+	}
+	if balance != 42 || requests.Load() != 3 {
+		t.Errorf("balance = %d after %d requests, want 42 after 3", balance, requests.Load())
+	}
+}
+
+func TestClient_DoesNotRetryClientError(t *testing.T) {
+	srv, requests := flakyServer(t, 10, http.StatusBadRequest)
+	client := newRetryClient()
+
+	if _, err := client.QueryBalance(context.Background(), testAddress, srv.URL, models.Denom); err == nil {
+		t.Fatal("expected error for 400 response")
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("got %d requests, want 1", got)
+	}
+}
+
+func TestClient_DoesNotRetryInternalError(t *testing.T) {
+	srv, requests := flakyServer(t, 10, http.StatusInternalServerError)
+	client := newRetryClient()
+
+	if _, err := client.QueryBalance(context.Background(), testAddress, srv.URL, models.Denom); err == nil {
+		t.Fatal("expected error for 500 response")
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("got %d requests, want 1", got)
+	}
+}
+
+func TestClient_RetriesExhausted(t *testing.T) {
+	srv, requests := flakyServer(t, 10, http.StatusServiceUnavailable)
+	client := newRetryClient()
+
+	_, err := client.QueryBalance(context.Background(), testAddress, srv.URL, models.Denom)
+	if err == nil {
+		t.Fatal("expected error after retries are exhausted")
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("got %d requests, want 3", got)
+	}
+}
+
+func TestClient_CircuitBreaker(t *testing.T) {
+	srv, requests := flakyServer(t, 4, http.StatusServiceUnavailable)
+	client := newRetryClient()
+	client.Retry.Attempts = 1
+	client.Endpoints.BreakerFailures = 2
+	client.Endpoints.BreakerCooldown = 50 * time.Millisecond
+
+	for i := 0; i < 2; i++ {
+		client.QueryBalance(context.Background(), testAddress, srv.URL, models.Denom)
+	}
+
+	// The circuit is open: fail fast without reaching the server.
+	_, err := client.QueryBalance(context.Background(), testAddress, srv.URL, models.Denom)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("QueryBalance() error = %v, want ErrCircuitOpen", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("got %d requests while open, want 2", got)
+	}
+
+	// After the cooldown a failed trial re-opens the circuit at once.
+	time.Sleep(60 * time.Millisecond)
+	client.QueryBalance(context.Background(), testAddress, srv.URL, models.Denom)
+	if _, err := client.QueryBalance(context.Background(), testAddress, srv.URL, models.Denom); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("after failed trial: error = %v, want ErrCircuitOpen", err)
+	}
+
+	// A successful trial closes it.
+	time.Sleep(60 * time.Millisecond)
+	requests.Store(10)
+	if _, err := client.QueryBalance(context.Background(), testAddress, srv.URL, models.Denom); err != nil {
+		t.Fatalf("trial QueryBalance() error = %v", err)
+	}
+	if _, err := client.QueryBalance(context.Background(), testAddress, srv.URL, models.Denom); err != nil {
+		t.Errorf("closed circuit QueryBalance() error = %v", err)
+	}
+}
+
+func TestClient_CircuitBreakerSingleTrial(t *testing.T) {
+	var requests atomic.Int32
+	arrived, release := make(chan struct{}, 10), make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		arrived <- struct{}{}
+		<-release
+		fmt.Fprint(w, `{"balances":[{"denom":"upokt","amount":"42"}]}`)
+	}))
+	defer srv.Close()
+
+	client := newRetryClient()
+	client.Retry.Attempts = 1
+	client.Endpoints.BreakerFailures = 2
+	client.Endpoints.BreakerCooldown = 20 * time.Millisecond
+	for i := 0; i < 2; i++ {
+		client.QueryBalance(context.Background(), testAddress, srv.URL, models.Denom)
+	}
+	time.Sleep(30 * time.Millisecond)
+
+	// Half-open: of many concurrent requests only one reaches the server as
+	// the trial; the rest fail fast until it is recorded.
+	var wg sync.WaitGroup
+	var rejected atomic.Int32
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.QueryBalance(context.Background(), testAddress, srv.URL, models.Denom); errors.Is(err, ErrCircuitOpen) {
+				rejected.Add(1)
+			}
+		}()
+	}
+	<-arrived
+	for deadline := time.Now().Add(5 * time.Second); rejected.Load() < 9 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if got := requests.Load(); got != 3 {
+		t.Errorf("got %d requests, want 2 failures and 1 trial", got)
+	}
+	// The successful trial closed the circuit.
+	if _, err := client.QueryBalance(context.Background(), testAddress, srv.URL, models.Denom); err != nil {
+		t.Errorf("closed circuit QueryBalance() error = %v", err)
+	}
+}
+
+func TestProbeEndpoints_LeavesCircuitToRequests(t *testing.T) {
+	var failing atomic.Bool
+	failing.Store(true)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/cosmos/base/tendermint/v1beta1/blocks/latest" {
+			fmt.Fprint(w, `{"block":{"header":{"height":"100"}}}`)
+			return
+		}
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"balances":[{"denom":"upokt","amount":"42"}]}`)
+	}))
+	defer srv.Close()
+
+	client := newEndpointsClient([]string{srv.URL}, []string{srv.URL})
+	client.Endpoints.BreakerFailures = 2
+	client.Endpoints.BreakerCooldown = 20 * time.Millisecond
+	for i := 0; i < 2; i++ {
+		client.QueryBalance(context.Background(), testAddress, srv.URL, models.Denom)
+	}
+
+	// A successful probe while open doesn't close the circuit.
+	client.ProbeEndpoints(context.Background())
+	if _, err := client.QueryBalance(context.Background(), testAddress, srv.URL, models.Denom); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("after probe: error = %v, want ErrCircuitOpen", err)
+	}
+
+	// Nor does it end a half-open trial in flight: only the trial's own
+	// outcome lets the next request through.
+	time.Sleep(30 * time.Millisecond)
+	_, candidates := client.Endpoints.candidates(EndpointAPI, srv.URL)
+	ep := candidates[0]
+	if !client.Endpoints.allow(ep) {
+		t.Fatal("allow() = false, want the half-open trial")
+	}
+	client.ProbeEndpoints(context.Background())
+	if client.Endpoints.allow(ep) {
+		t.Error("allow() = true while the trial is still in flight")
+	}
+	failing.Store(false)
+	client.Endpoints.record(ep, time.Millisecond, nil)
+	if _, err := client.QueryBalance(context.Background(), testAddress, srv.URL, models.Denom); err != nil {
+		t.Errorf("closed circuit QueryBalance() error = %v", err)
+	}
+}
+
+func TestRetryableError(t *testing.T) {
+	client := newRetryClient()
+
+	// Nothing listens on a closed server's port.
+	srv := httptest.NewServer(http.NotFoundHandler())
+	addr := srv.URL
+	srv.Close()
+
+	_, err := client.HTTP.Get(addr)
+	if err == nil || !retryableError(err) {
+		t.Errorf("connection refused: retryableError(%v) = false, want true", err)
+	}
+	if retryableError(errors.New("redirect to different host blocked")) {
+		t.Error("blocked redirect should not be retryable")
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+	for n, limit := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 5: 300 * time.Millisecond} {
+		for i := 0; i < 20; i++ {
+			if d := p.backoff(n); d < limit/2 || d >= limit {
+				t.Errorf("backoff(%d) = %v, want in [%v, %v)", n, d, limit/2, limit)
+			}
+		}
+	}
+}
@@ -0,0 +1,634 @@
+package pocket
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/pokt-network/sam/internal/config"
+	"github.com/pokt-network/sam/internal/models"
+	"github.com/pokt-network/sam/internal/pubkey"
+)
+
+// SignModeAminoJSON is the sign mode used for transactions signed outside
+// the pocketd keyring. Its sign-bytes can be built without protobuf.
+const SignModeAminoJSON = "SIGN_MODE_LEGACY_AMINO_JSON"
+
+// Public key type URLs attached to signatures.
+const (
+	PubKeySecp256k1 = pubkey.Secp256k1Type
+	PubKeyEd25519   = "/cosmos.crypto.ed25519.PubKey"
+)
+
+// SignerData identifies the account and chain a transaction is signed for.
+type SignerData struct {
+	Address       string
+	ChainID       string
+	AccountNumber uint64
+	Sequence      uint64
+}
+
+// Signer signs an unsigned transaction (Cosmos SDK JSON, as produced by
+// --generate-only) and returns the signed transaction.
+type Signer interface {
+	SignTx(ctx context.Context, unsignedTx []byte, data SignerData) ([]byte, error)
+}
+
+// aminoNames maps the message and nested Any type URLs SAM produces to
+// their registered amino names: the cosmos-sdk ones from each module's
+// amino codec (RegisterLegacyAminoCodec), and MsgStakeApplication's from the
+// amino.name option in poktroll's proto/pocket/application/tx.proto.
+var aminoNames = map[string]string{
+	"/cosmos.bank.v1beta1.MsgSend":                "cosmos-sdk/MsgSend",
+	"/cosmos.authz.v1beta1.MsgGrant":              "cosmos-sdk/MsgGrant",
+	"/cosmos.authz.v1beta1.MsgRevoke":             "cosmos-sdk/MsgRevoke",
+	"/cosmos.authz.v1beta1.MsgExec":               "cosmos-sdk/MsgExec",
+	"/cosmos.authz.v1beta1.GenericAuthorization":  "cosmos-sdk/GenericAuthorization",
+	"/cosmos.feegrant.v1beta1.MsgGrantAllowance":  "cosmos-sdk/MsgGrantAllowance",
+	"/cosmos.feegrant.v1beta1.MsgRevokeAllowance": "cosmos-sdk/MsgRevokeAllowance",
+	"/cosmos.feegrant.v1beta1.BasicAllowance":     "cosmos-sdk/BasicAllowance",
+	MsgStakeApplicationType:                       "pocket/x/application/MsgStakeApplication",
+}
+
+// unsignedTx is the part of a generated transaction that goes into the
+// amino sign doc.
+type unsignedTx struct {
+	Body struct {
+		Messages      []json.RawMessage `json:"messages"`
+		Memo          string            `json:"memo"`
+		TimeoutHeight string            `json:"timeout_height"`
+	} `json:"body"`
+	AuthInfo struct {
+		Fee struct {
+			Amount   []json.RawMessage `json:"amount"`
+			GasLimit string            `json:"gas_limit"`
+			Payer    string            `json:"payer"`
+			Granter  string            `json:"granter"`
+		} `json:"fee"`
+	} `json:"auth_info"`
+}
+
+// AminoSignBytes returns the SIGN_MODE_LEGACY_AMINO_JSON sign-bytes for an
+// unsigned transaction: the sorted, compact JSON of its StdSignDoc.
+func AminoSignBytes(unsigned []byte, data SignerData) ([]byte, error) {
+	var tx unsignedTx
+	if err := json.Unmarshal(unsigned, &tx); err != nil {
+		return nil, fmt.Errorf("failed to parse unsigned tx: %w", err)
+	}
+	if len(tx.Body.Messages) == 0 {
+		return nil, fmt.Errorf("unsigned tx has no messages")
+	}
+
+	msgs := make([]any, 0, len(tx.Body.Messages))
+	for i, raw := range tx.Body.Messages {
+		msg, err := decodeJSON(raw)
+		if err != nil {
+			return nil, fmt.Errorf("message %d: %w", i, err)
+		}
+		converted, err := toAmino(msg, nil)
+		if err != nil {
+			return nil, fmt.Errorf("message %d: %w", i, err)
+		}
+		msgs = append(msgs, converted)
+	}
+
+	amount := make([]any, 0, len(tx.AuthInfo.Fee.Amount))
+	for _, raw := range tx.AuthInfo.Fee.Amount {
+		coin, err := decodeJSON(raw)
+		if err != nil {
+			return nil, fmt.Errorf("fee amount: %w", err)
+		}
+		converted, err := toAmino(coin, coinRules)
+		if err != nil {
+			return nil, fmt.Errorf("fee amount: %w", err)
+		}
+		amount = append(amount, converted)
+	}
+
+	// The fee amount is dont_omitempty: a fee-less transaction signs "amount":[].
+	fee := map[string]any{
+		"amount": amount,
+		"gas":    tx.AuthInfo.Fee.GasLimit,
+	}
+	if tx.AuthInfo.Fee.Payer != "" {
+		fee["payer"] = tx.AuthInfo.Fee.Payer
+	}
+	if tx.AuthInfo.Fee.Granter != "" {
+		fee["granter"] = tx.AuthInfo.Fee.Granter
+	}
+
+	doc := map[string]any{
+		"account_number": strconv.FormatUint(data.AccountNumber, 10),
+		"chain_id":       data.ChainID,
+		"fee":            fee,
+		"memo":           tx.Body.Memo,
+		"msgs":           msgs,
+		"sequence":       strconv.FormatUint(data.Sequence, 10),
+	}
+	if tx.Body.TimeoutHeight != "" && tx.Body.TimeoutHeight != "0" {
+		doc["timeout_height"] = tx.Body.TimeoutHeight
+	}
+
+	// encoding/json sorts map keys, which gives the canonical ordering.
+	return json.Marshal(doc)
+}
+
+func decodeJSON(raw []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// aminoMessage holds the amino JSON rules of a protobuf message: the fields
+// marked (amino.dont_omitempty), which keep their default value, and the
+// rules of nested message fields (single or repeated). Fields holding an Any
+// get their rules from the packed type URL instead.
+type aminoMessage struct {
+	keep   map[string]bool
+	fields map[string]*aminoMessage
+}
+
+func (m *aminoMessage) keeps(field string) bool {
+	return m != nil && m.keep[field]
+}
+
+func (m *aminoMessage) field(name string) *aminoMessage {
+	if m == nil {
+		return nil
+	}
+	return m.fields[name]
+}
+
+// coinRules are cosmos.base.v1beta1.Coin's: amount is dont_omitempty, so a
+// zero coin still signs as "0".
+var coinRules = &aminoMessage{keep: map[string]bool{"amount": true}}
+
+// aminoRules maps the type URLs in aminoNames to their message's rules, taken
+// from the amino options in the cosmos-sdk and poktroll protos. Types that
+// are not listed have no dont_omitempty fields and no nested messages.
+var aminoRules = map[string]*aminoMessage{
+	"/cosmos.bank.v1beta1.MsgSend": {
+		keep:   map[string]bool{"amount": true},
+		fields: map[string]*aminoMessage{"amount": coinRules},
+	},
+	"/cosmos.authz.v1beta1.MsgGrant": {
+		keep: map[string]bool{"grant": true},
+	},
+	"/cosmos.feegrant.v1beta1.BasicAllowance": {
+		keep:   map[string]bool{"spend_limit": true},
+		fields: map[string]*aminoMessage{"spend_limit": coinRules},
+	},
+	MsgStakeApplicationType: {
+		fields: map[string]*aminoMessage{"stake": coinRules},
+	},
+}
+
+// toAmino rewrites protobuf JSON into amino JSON: Any values become
+// {"type", "value"} pairs, and fields holding their proto3 default are
+// dropped, as amino's omitempty does, unless rules marks them
+// dont_omitempty.
+func toAmino(v any, rules *aminoMessage) (any, error) {
+	switch val := v.(type) {
+	case map[string]any:
+		typeURL, isAny := val["@type"].(string)
+		if isAny {
+			rules = aminoRules[typeURL]
+		}
+
+		out := make(map[string]any, len(val))
+		for k, child := range val {
+			if k == "@type" {
+				continue
+			}
+			converted, err := toAmino(child, rules.field(k))
+			if err != nil {
+				return nil, err
+			}
+			if isDefaultJSON(converted) && !rules.keeps(k) {
+				continue
+			}
+			out[k] = converted
+		}
+		if !isAny {
+			return out, nil
+		}
+		name, ok := aminoNames[typeURL]
+		if !ok {
+			return nil, fmt.Errorf("no amino name registered for %s", typeURL)
+		}
+		return map[string]any{"type": name, "value": out}, nil
+	case []any:
+		out := make([]any, 0, len(val))
+		for _, child := range val {
+			converted, err := toAmino(child, rules)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, converted)
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
+
+// isDefaultJSON reports whether v is a proto3 default in protobuf JSON: an
+// unset message (null), false, a numeric zero, an empty string or an empty
+// list. A set message is never a default, even when empty. 64-bit integers
+// are strings whose default is "0", but none of the messages in aminoNames
+// has one; every "0" they carry is a string such as a coin amount.
+func isDefaultJSON(v any) bool {
+	switch val := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !val
+	case json.Number:
+		f, err := val.Float64()
+		return err == nil && f == 0
+	case string:
+		return val == ""
+	case []any:
+		return len(val) == 0
+	}
+	return false
+}
+
+// attachSignature adds a single amino-JSON signature and its public key to
+// an unsigned transaction.
+func attachSignature(unsigned []byte, data SignerData, pubKeyType string, pubKey, signature []byte) ([]byte, error) {
+	var tx map[string]any
+	dec := json.NewDecoder(bytes.NewReader(unsigned))
+	dec.UseNumber()
+	if err := dec.Decode(&tx); err != nil {
+		return nil, fmt.Errorf("failed to parse unsigned tx: %w", err)
+	}
+
+	authInfo, ok := tx["auth_info"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unsigned tx has no auth_info")
+	}
+
+	authInfo["signer_infos"] = []any{
+		map[string]any{
+			"public_key": map[string]any{
+				"@type": pubKeyType,
+				"key":   base64.StdEncoding.EncodeToString(pubKey),
+			},
+			"mode_info": map[string]any{
+				"single": map[string]any{"mode": SignModeAminoJSON},
+			},
+			"sequence": strconv.FormatUint(data.Sequence, 10),
+		},
+	}
+	tx["signatures"] = []any{base64.StdEncoding.EncodeToString(signature)}
+
+	return json.Marshal(tx)
+}
+
+// writeTempTx writes a transaction to a private temp file and returns its path.
+func writeTempTx(pattern string, tx []byte) (string, error) {
+	tempFile, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp tx file: %w", err)
+	}
+	if err := tempFile.Chmod(0600); err != nil {
+		tempFile.Close()
+		os.Remove(tempFile.Name())
+		return "", fmt.Errorf("failed to set temp file permissions: %w", err)
+	}
+	if _, err := tempFile.Write(tx); err != nil {
+		tempFile.Close()
+		os.Remove(tempFile.Name())
+		return "", fmt.Errorf("failed to write temp tx file: %w", err)
+	}
+	if err := tempFile.Close(); err != nil {
+		os.Remove(tempFile.Name())
+		return "", fmt.Errorf("failed to close temp tx file: %w", err)
+	}
+	return tempFile.Name(), nil
+}
+
+// KeyringSigner signs with a key in the local pocketd keyring
+// (pocketd tx sign --offline).
+type KeyringSigner struct {
+	Executor *Executor
+}
+
+// SignTx signs the transaction with the keyring key for data.Address.
+func (s *KeyringSigner) SignTx(ctx context.Context, unsigned []byte, data SignerData) ([]byte, error) {
+	tempTx, err := writeTempTx("pocketd-unsigned-*.json", unsigned)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tempTx)
+
+	args := []string{
+		"tx", "sign", tempTx,
+		"--from", data.Address,
+		"--offline",
+		"--account-number", strconv.FormatUint(data.AccountNumber, 10),
+		"--sequence", strconv.FormatUint(data.Sequence, 10),
+		"--chain-id", data.ChainID,
+		"--sign-mode", "amino-json",
+		"--output", "json",
+	}
+
+	if s.Executor.Config.Config.KeyringBackend != "" {
+		args = append(args, "--keyring-backend", s.Executor.Config.Config.KeyringBackend)
+	}
+
+	output, err := s.Executor.Run(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("keyring sign failed: %w", err)
+	}
+	if !json.Valid([]byte(output)) {
+		return nil, fmt.Errorf("pocketd returned a non-JSON signed transaction")
+	}
+	return []byte(output), nil
+}
+
+// RemoteSignRequest is the body POSTed to a remote signer.
+type RemoteSignRequest struct {
+	Address       string `json:"address"`
+	ChainID       string `json:"chain_id"`
+	AccountNumber uint64 `json:"account_number"`
+	Sequence      uint64 `json:"sequence"`
+	SignMode      string `json:"sign_mode"`
+	SignBytes     []byte `json:"sign_bytes"` // base64 in JSON
+}
+
+// RemoteSignResponse is the body a remote signer returns.
+type RemoteSignResponse struct {
+	Signature     []byte `json:"signature"`       // base64 in JSON
+	PublicKey     []byte `json:"public_key"`      // base64 in JSON
+	PublicKeyType string `json:"public_key_type"` // defaults to secp256k1
+}
+
+// RemoteSigner sends sign-bytes to an HTTP signing service (a KMS or vault
+// front end) and attaches the signature it returns.
+type RemoteSigner struct {
+	Endpoint string
+	Token    string
+	HTTP     *http.Client
+}
+
+// NewRemoteSigner returns a RemoteSigner for the endpoint. A non-empty
+// token is sent as a bearer token.
+func NewRemoteSigner(endpoint, token string) *RemoteSigner {
+	return &RemoteSigner{
+		Endpoint: endpoint,
+		Token:    token,
+		HTTP:     &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// SignTx signs the transaction through the remote endpoint.
+func (s *RemoteSigner) SignTx(ctx context.Context, unsigned []byte, data SignerData) ([]byte, error) {
+	signBytes, err := AminoSignBytes(unsigned, data)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(RemoteSignRequest{
+		Address:       data.Address,
+		ChainID:       data.ChainID,
+		AccountNumber: data.AccountNumber,
+		Sequence:      data.Sequence,
+		SignMode:      SignModeAminoJSON,
+		SignBytes:     signBytes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode sign request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sign request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.Token)
+	}
+
+	resp, err := s.HTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read remote signer response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("remote signer returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var signed RemoteSignResponse
+	if err := json.Unmarshal(respBody, &signed); err != nil {
+		return nil, fmt.Errorf("failed to parse remote signer response: %w", err)
+	}
+	if len(signed.Signature) == 0 || len(signed.PublicKey) == 0 {
+		return nil, fmt.Errorf("remote signer response is missing signature or public key")
+	}
+
+	keyType := signed.PublicKeyType
+	if keyType == "" {
+		keyType = PubKeySecp256k1
+	}
+
+	// A signature from any other key would only fail on chain; catch a
+	// misrouted or misconfigured signer before broadcasting.
+	addr, err := pubkey.Address(keyType, signed.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer returned an invalid public key: %w", err)
+	}
+	if addr != data.Address {
+		return nil, fmt.Errorf("remote signer returned the public key of %s, not %s", addr, data.Address)
+	}
+
+	return attachSignature(unsigned, data, keyType, signed.PublicKey, signed.Signature)
+}
+
+// LocalSigner signs in-process with an ed25519 key. It stands in for a real
+// signer in tests and local development.
+type LocalSigner struct {
+	Key ed25519.PrivateKey
+}
+
+// NewLocalSigner returns a LocalSigner with a freshly generated key.
+func NewLocalSigner() (*LocalSigner, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	return &LocalSigner{Key: key}, nil
+}
+
+// PublicKey returns the signer's public key.
+func (s *LocalSigner) PublicKey() ed25519.PublicKey {
+	return s.Key.Public().(ed25519.PublicKey)
+}
+
+// SignTx signs the amino sign-bytes of the transaction with the local key.
+func (s *LocalSigner) SignTx(_ context.Context, unsigned []byte, data SignerData) ([]byte, error) {
+	signBytes, err := AminoSignBytes(unsigned, data)
+	if err != nil {
+		return nil, err
+	}
+	return attachSignature(unsigned, data, PubKeyEd25519, s.PublicKey(), ed25519.Sign(s.Key, signBytes))
+}
+
+// newSigners builds the signer set from config. The local keyring is always
+// available under config.SignerKeyring.
+func newSigners(e *Executor) map[string]Signer {
+	signers := map[string]Signer{config.SignerKeyring: &KeyringSigner{Executor: e}}
+	for name, sc := range e.Config.Config.Signers {
+		switch sc.Type {
+		case config.SignerKeyring:
+			signers[name] = &KeyringSigner{Executor: e}
+		case config.SignerRemote:
+			var token string
+			if sc.TokenEnv != "" {
+				token = os.Getenv(sc.TokenEnv)
+			}
+			signers[name] = NewRemoteSigner(sc.Endpoint, token)
+		}
+	}
+	return signers
+}
+
+// QueryAccount returns the account number and sequence of an address.
+func (c *Client) QueryAccount(ctx context.Context, address, apiEndpoint string) (uint64, uint64, error) {
+	path := fmt.Sprintf("/cosmos/auth/v1beta1/accounts/%s", address)
+	c.Logger.Debug("querying account", "endpoint", apiEndpoint, "path", path)
+
+	resp, err := c.get(ctx, apiEndpoint, path)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to query account API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+		return 0, 0, fmt.Errorf("account API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read account response: %w", err)
+	}
+
+	var accResp models.APIAccountResponse
+	if err := json.Unmarshal(body, &accResp); err != nil {
+		return 0, 0, fmt.Errorf("failed to parse account response: %w", err)
+	}
+
+	accountNumber, err := strconv.ParseUint(accResp.Account.AccountNumber, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to parse account number: %w", err)
+	}
+	sequence, err := strconv.ParseUint(accResp.Account.Sequence, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to parse account sequence: %w", err)
+	}
+
+	return accountNumber, sequence, nil
+}
+
+// externalSigner returns the configured signer name for a transaction when
+// it is not the local keyring. The network is taken from --chain-id.
+func (e *Executor) externalSigner(from string, args []string) (string, bool) {
+	network := argValue(args, "--chain-id")
+	netCfg, ok := e.Config.Config.Networks[network]
+	if !ok {
+		return "", false
+	}
+	name := netCfg.SignerFor(from)
+	if name == config.SignerKeyring {
+		return "", false
+	}
+	return name, true
+}
+
+// signAndBroadcast generates a transaction unsigned, signs it with the named
+// signer and broadcasts the result.
+func (e *Executor) signAndBroadcast(ctx context.Context, op, from, name string, args []string) (*models.TransactionResponse, error) {
+	signer, ok := e.Signers[name]
+	if !ok {
+		return nil, fmt.Errorf("signer %q is not configured", name)
+	}
+
+	network := argValue(args, "--chain-id")
+	rpcEndpoint := argValue(args, "--node")
+	netCfg := e.Config.Config.Networks[network]
+
+	genArgs := make([]string, 0, len(args)+3)
+	for _, arg := range args {
+		switch arg {
+		case "--yes":
+			continue
+		case "--gas=auto":
+			genArgs = append(genArgs, "--gas", generateOnlyGas)
+			continue
+		}
+		genArgs = append(genArgs, arg)
+	}
+	genArgs = append(genArgs, "--generate-only")
+
+	e.Logger.Debug(op+" generate command", "args", genArgs, "signer", name)
+
+	unsigned, err := e.Run(ctx, genArgs...)
+	if err != nil {
+		e.Logger.Error(op+" generate command failed", "error", err)
+		return &models.TransactionResponse{
+			Success: false,
+			Message: op + " transaction failed",
+		}, nil
+	}
+
+	accountNumber, sequence, err := e.Client.QueryAccount(ctx, from, netCfg.APIEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query signer account: %w", err)
+	}
+
+	signed, err := signer.SignTx(ctx, []byte(unsigned), SignerData{
+		Address:       from,
+		ChainID:       network,
+		AccountNumber: accountNumber,
+		Sequence:      sequence,
+	})
+	if err != nil {
+		e.Logger.Error(op+" signing failed", "signer", name, "error", err)
+		return &models.TransactionResponse{
+			Success: false,
+			Message: op + " signing failed",
+		}, nil
+	}
+
+	return e.BroadcastSigned(ctx, signed, network, rpcEndpoint)
+}
+
+// argValue returns the value following flag in args, or "".
+func argValue(args []string, flag string) string {
+	for i := 0; i < len(args)-1; i++ {
+		if args[i] == flag {
+			return args[i+1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,121 @@
+package pocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pokt-network/sam/internal/models"
+)
+
+const (
+	latestBlockPath = "/cosmos/base/tendermint/v1beta1/blocks/latest"
+	statusPath      = "/status"
+)
+
+// apiLatestBlock is the REST latest-block response, trimmed to the header.
+type apiLatestBlock struct {
+	Block struct {
+		Header struct {
+			Height string    `json:"height"`
+			Time   time.Time `json:"time"`
+		} `json:"header"`
+	} `json:"block"`
+}
+
+// rpcStatus is the CometBFT /status response, trimmed to the sync info.
+type rpcStatus struct {
+	Result struct {
+		SyncInfo struct {
+			LatestBlockHeight string    `json:"latest_block_height"`
+			LatestBlockTime   time.Time `json:"latest_block_time"`
+			CatchingUp        bool      `json:"catching_up"`
+		} `json:"sync_info"`
+	} `json:"result"`
+}
+
+func (b apiLatestBlock) status() (*models.NodeStatus, error) {
+	height, err := parseBlockHeight(b.Block.Header.Height)
+	if err != nil {
+		return nil, err
+	}
+	return &models.NodeStatus{Height: height, BlockTime: b.Block.Header.Time}, nil
+}
+
+func (s rpcStatus) status() (*models.NodeStatus, error) {
+	info := s.Result.SyncInfo
+	height, err := parseBlockHeight(info.LatestBlockHeight)
+	if err != nil {
+		return nil, err
+	}
+	return &models.NodeStatus{Height: height, CatchingUp: info.CatchingUp, BlockTime: info.LatestBlockTime}, nil
+}
+
+func parseBlockHeight(raw string) (int64, error) {
+	height, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid block height %q", raw)
+	}
+	return height, nil
+}
+
+// QueryLatestBlock returns the height and time of the latest block served
+// by the network's REST API.
+func (c *Client) QueryLatestBlock(ctx context.Context, apiEndpoint string) (*models.NodeStatus, error) {
+	var block apiLatestBlock
+	if err := c.getJSON(ctx, apiEndpoint, latestBlockPath, "latest block", &block); err != nil {
+		return nil, err
+	}
+	return block.status()
+}
+
+// QueryNodeStatus returns the CometBFT /status of the network's preferred
+// RPC endpoint: its latest block and whether it is catching up.
+func (c *Client) QueryNodeStatus(ctx context.Context, rpcEndpoint string) (*models.NodeStatus, error) {
+	return c.nodeStatus(ctx, EndpointRPC, c.Endpoints.Pick(EndpointRPC, rpcEndpoint))
+}
+
+// nodeStatus asks one endpoint for its status, without retries or
+// failover: the REST latest-block query for API endpoints, CometBFT
+// /status for RPC endpoints.
+func (c *Client) nodeStatus(ctx context.Context, kind, url string) (*models.NodeStatus, error) {
+	path := latestBlockPath
+	if kind == EndpointRPC {
+		path = statusPath
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("returned status %d", resp.StatusCode)
+	}
+
+	if kind == EndpointRPC {
+		var status rpcStatus
+		if err := json.Unmarshal(body, &status); err != nil {
+			return nil, fmt.Errorf("failed to parse status: %w", err)
+		}
+		return status.status()
+	}
+	var block apiLatestBlock
+	if err := json.Unmarshal(body, &block); err != nil {
+		return nil, fmt.Errorf("failed to parse latest block: %w", err)
+	}
+	return block.status()
+}
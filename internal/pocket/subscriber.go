@@ -0,0 +1,279 @@
+package pocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/pokt-network/sam/internal/config"
+)
+
+// Chain event kinds.
+const (
+	EventBlock = "block" // block-level events, such as claim settlements that burn app stake
+	EventTx    = "tx"    // transaction events, such as transfers and stakes
+)
+
+const (
+	// The CometBFT event queries a Subscriber follows.
+	blockQuery = "tm.event='NewBlock'"
+	txQuery    = "tm.event='Tx'"
+
+	// maxEventSize caps one websocket message; NewBlock carries the block.
+	maxEventSize = 32 << 20
+
+	// DefaultSubscriberReadTimeout is how long a connection may go without
+	// a message or ping before it is dropped and redialed.
+	DefaultSubscriberReadTimeout = 2 * time.Minute
+)
+
+// addressInText finds addresses in event attribute values, which may be
+// plain addresses or JSON-encoded typed events that embed them.
+var addressInText = regexp.MustCompile(`pokt1[a-z0-9]{38}`)
+
+// ChainEvent reports a block or transaction that touched tracked
+// addresses.
+type ChainEvent struct {
+	Network   string
+	Kind      string // EventBlock or EventTx
+	Height    int64
+	Addresses []string // tracked addresses found in the event attributes
+}
+
+// Subscriber watches a network's CometBFT websocket for new blocks and
+// transactions and calls OnEvent for those touching the network's bank or
+// applications. It redials with backoff after a disconnect; polling covers
+// whatever happened while it was down.
+type Subscriber struct {
+	Config      *config.Config
+	Network     string
+	RPCEndpoint string     // primary RPC endpoint; the connection follows Endpoints' choice
+	Endpoints   *Endpoints // nil: always the primary
+	OnEvent     func(ChainEvent)
+	Logger      *slog.Logger
+	Dialer      *websocket.Dialer
+
+	// ReadTimeout drops a silent connection; MinBackoff and MaxBackoff
+	// bound the wait between redials.
+	ReadTimeout time.Duration
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+}
+
+// NewSubscriber creates a subscriber for one configured network.
+func NewSubscriber(cfg *config.Config, network string, endpoints *Endpoints, onEvent func(ChainEvent), logger *slog.Logger) *Subscriber {
+	return &Subscriber{
+		Config:      cfg,
+		Network:     network,
+		RPCEndpoint: cfg.Config.Networks[network].RPCEndpoint,
+		Endpoints:   endpoints,
+		OnEvent:     onEvent,
+		Logger:      logger,
+		Dialer:      websocket.DefaultDialer,
+		ReadTimeout: DefaultSubscriberReadTimeout,
+		MinBackoff:  time.Second,
+		MaxBackoff:  time.Minute,
+	}
+}
+
+// Run keeps a subscription open until ctx is cancelled.
+func (s *Subscriber) Run(ctx context.Context) {
+	backoff := s.MinBackoff
+	for {
+		subscribed, err := s.subscribe(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if subscribed {
+			backoff = s.MinBackoff
+		}
+		s.Logger.Warn("chain event subscription lost, reconnecting",
+			"network", s.Network, "error", err, "backoff", backoff)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, s.MaxBackoff)
+	}
+}
+
+// rpcMessage is a JSON-RPC response or event pushed over the websocket.
+type rpcMessage struct {
+	Result struct {
+		Query string `json:"query"`
+		Data  struct {
+			Value json.RawMessage `json:"value"`
+		} `json:"data"`
+		Events map[string][]string `json:"events"`
+	} `json:"result"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    string `json:"data"`
+	} `json:"error"`
+}
+
+// subscribe dials the websocket, subscribes to blocks and transactions and
+// handles events until the connection fails. It reports whether both
+// subscriptions were acknowledged.
+func (s *Subscriber) subscribe(ctx context.Context) (subscribed bool, err error) {
+	wsURL, err := websocketURL(s.Endpoints.Pick(EndpointRPC, s.RPCEndpoint))
+	if err != nil {
+		return false, err
+	}
+	conn, _, err := s.Dialer.DialContext(ctx, wsURL, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to dial %s: %w", wsURL, err)
+	}
+	defer conn.Close()
+
+	// Unblock ReadMessage on shutdown.
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-stop:
+		}
+	}()
+
+	conn.SetReadLimit(maxEventSize)
+	conn.SetReadDeadline(time.Now().Add(s.ReadTimeout))
+	conn.SetPingHandler(func(data string) error {
+		conn.SetReadDeadline(time.Now().Add(s.ReadTimeout))
+		return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(10*time.Second))
+	})
+
+	for id, query := range []string{blockQuery, txQuery} {
+		req := map[string]any{
+			"jsonrpc": "2.0",
+			"method":  "subscribe",
+			"id":      id + 1,
+			"params":  map[string]string{"query": query},
+		}
+		if err := conn.WriteJSON(req); err != nil {
+			return false, fmt.Errorf("failed to subscribe to %s: %w", query, err)
+		}
+	}
+
+	acks := 0
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return acks == 2, err
+		}
+		conn.SetReadDeadline(time.Now().Add(s.ReadTimeout))
+
+		var msg rpcMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			s.Logger.Warn("failed to parse chain event", "network", s.Network, "error", err)
+			continue
+		}
+		if msg.Error != nil {
+			return acks == 2, fmt.Errorf("subscription error %d: %s %s", msg.Error.Code, msg.Error.Message, msg.Error.Data)
+		}
+		if msg.Result.Query == "" {
+			// An empty result acknowledges a subscribe request.
+			if acks++; acks == 2 {
+				s.Logger.Info("subscribed to chain events", "network", s.Network, "endpoint", wsURL)
+			}
+			continue
+		}
+		s.handle(msg)
+	}
+}
+
+// handle reports the tracked addresses found in one event.
+func (s *Subscriber) handle(msg rpcMessage) {
+	tracked := s.tracked()
+	found := make(map[string]bool)
+	for _, values := range msg.Result.Events {
+		for _, v := range values {
+			for _, address := range addressInText.FindAllString(v, -1) {
+				if tracked[address] {
+					found[address] = true
+				}
+			}
+		}
+	}
+	if len(found) == 0 {
+		return
+	}
+
+	event := ChainEvent{Network: s.Network, Kind: EventTx}
+	if msg.Result.Query == blockQuery {
+		event.Kind = EventBlock
+	}
+	event.Height = eventHeight(msg)
+	for address := range found {
+		event.Addresses = append(event.Addresses, address)
+	}
+
+	s.Logger.Debug("chain event for tracked addresses",
+		"network", s.Network, "kind", event.Kind, "height", event.Height, "addresses", event.Addresses)
+	s.OnEvent(event)
+}
+
+// tracked returns the network's bank and application addresses. It reads
+// the config on every event, so apps added at runtime are watched too.
+func (s *Subscriber) tracked() map[string]bool {
+	netCfg := s.Config.Config.Networks[s.Network]
+	tracked := make(map[string]bool, len(netCfg.Applications)+1)
+	for _, address := range netCfg.Applications {
+		tracked[address] = true
+	}
+	if netCfg.Bank != "" {
+		tracked[netCfg.Bank] = true
+	}
+	return tracked
+}
+
+// eventHeight reads the height of a Tx event from its tx.height attribute,
+// or of a NewBlock event from the block header; 0 if neither is present.
+func eventHeight(msg rpcMessage) int64 {
+	if heights := msg.Result.Events["tx.height"]; len(heights) > 0 {
+		height, _ := strconv.ParseInt(heights[0], 10, 64)
+		return height
+	}
+	var block struct {
+		Block struct {
+			Header struct {
+				Height string `json:"height"`
+			} `json:"header"`
+		} `json:"block"`
+	}
+	if json.Unmarshal(msg.Result.Data.Value, &block) != nil {
+		return 0
+	}
+	height, _ := strconv.ParseInt(block.Block.Header.Height, 10, 64)
+	return height
+}
+
+// websocketURL turns an RPC endpoint into its CometBFT websocket URL.
+func websocketURL(rpcEndpoint string) (string, error) {
+	u, err := url.Parse(rpcEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid RPC endpoint %q: %w", rpcEndpoint, err)
+	}
+	switch u.Scheme {
+	case "https", "wss":
+		u.Scheme = "wss"
+	case "http", "ws":
+		u.Scheme = "ws"
+	default:
+		return "", fmt.Errorf("invalid RPC endpoint %q: unsupported scheme", rpcEndpoint)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/websocket"
+	return u.String(), nil
+}
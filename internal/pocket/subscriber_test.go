@@ -0,0 +1,164 @@
+package pocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/pokt-network/sam/internal/config"
+)
+
+const (
+	subApp   = "pokt1aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	subBank  = "pokt1bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	subOther = "pokt1cccccccccccccccccccccccccccccccccccccc"
+)
+
+// cometServer is a CometBFT websocket stand-in. Each connection
+// acknowledges both subscriptions, pushes events and then closes.
+func cometServer(t *testing.T, connections *atomic.Int32, events ...string) *httptest.Server {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/websocket" {
+			http.NotFound(w, r)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		connections.Add(1)
+
+		for range 2 {
+			var req struct {
+				ID     int               `json:"id"`
+				Method string            `json:"method"`
+				Params map[string]string `json:"params"`
+			}
+			if err := conn.ReadJSON(&req); err != nil || req.Method != "subscribe" {
+				return
+			}
+			ack := fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":{}}`, req.ID)
+			conn.WriteMessage(websocket.TextMessage, []byte(ack))
+		}
+		for _, event := range events {
+			conn.WriteMessage(websocket.TextMessage, []byte(event))
+		}
+		conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func cometEvent(query string, events map[string][]string, value string) string {
+	msg := map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"result": map[string]any{
+			"query":  query,
+			"data":   map[string]any{"type": "tendermint/event", "value": json.RawMessage(value)},
+			"events": events,
+		},
+	}
+	b, _ := json.Marshal(msg)
+	return string(b)
+}
+
+func TestSubscriber_ReportsTrackedAddresses(t *testing.T) {
+	var connections atomic.Int32
+	srv := cometServer(t, &connections,
+		cometEvent(txQuery, map[string][]string{
+			"tm.event":           {"Tx"},
+			"tx.height":          {"41"},
+			"transfer.sender":    {subBank},
+			"transfer.recipient": {subApp},
+		}, `{}`),
+		cometEvent(txQuery, map[string][]string{
+			"tm.event":           {"Tx"},
+			"transfer.recipient": {subOther},
+		}, `{}`),
+		// Typed events embed the address in a JSON attribute value.
+		cometEvent(blockQuery, map[string][]string{
+			"tm.event": {"NewBlock"},
+			"pocket.tokenomics.EventClaimSettled.claim": {`{"session_header":{"application_address":"` + subApp + `"}}`},
+		}, `{"block":{"header":{"height":"42"}}}`),
+	)
+
+	cfg := &config.Config{}
+	cfg.Config.Networks = map[string]config.NetworkConfig{
+		"pocket": {RPCEndpoint: srv.URL, Bank: subBank, Applications: []string{subApp}},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	got := make(chan ChainEvent, 10)
+	onEvent := func(e ChainEvent) {
+		select {
+		case got <- e:
+		default: // redials replay the events
+		}
+	}
+	sub := NewSubscriber(cfg, "pocket", nil, onEvent, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	sub.MinBackoff = time.Millisecond
+	go sub.Run(ctx)
+
+	var events []ChainEvent
+	for len(events) < 2 {
+		select {
+		case e := <-got:
+			events = append(events, e)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("got %d events, want 2", len(events))
+		}
+	}
+
+	tx := events[0]
+	slices.Sort(tx.Addresses)
+	if tx.Kind != EventTx || tx.Height != 41 || !slices.Equal(tx.Addresses, []string{subApp, subBank}) {
+		t.Errorf("tx event = %+v", tx)
+	}
+	block := events[1]
+	if block.Kind != EventBlock || block.Height != 42 || !slices.Equal(block.Addresses, []string{subApp}) {
+		t.Errorf("block event = %+v", block)
+	}
+
+	// The stand-in closes every connection; the subscriber redials.
+	deadline := time.Now().Add(5 * time.Second)
+	for connections.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if n := connections.Load(); n < 2 {
+		t.Errorf("connections = %d, want a reconnect", n)
+	}
+}
+
+func TestWebsocketURL(t *testing.T) {
+	tests := []struct {
+		rpc, want string
+	}{
+		{"https://rpc.example.com", "wss://rpc.example.com/websocket"},
+		{"https://rpc.example.com/", "wss://rpc.example.com/websocket"},
+		{"http://localhost:26657", "ws://localhost:26657/websocket"},
+		{"https://example.com/rpc/", "wss://example.com/rpc/websocket"},
+	}
+	for _, tt := range tests {
+		if got, err := websocketURL(tt.rpc); err != nil || got != tt.want {
+			t.Errorf("websocketURL(%q) = %q, %v; want %q", tt.rpc, got, err, tt.want)
+		}
+	}
+	if _, err := websocketURL("tcp://rpc.example.com"); err == nil {
+		t.Error("websocketURL(tcp://) error = nil")
+	}
+}
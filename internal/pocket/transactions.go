@@ -1,6 +1,7 @@
 package pocket
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -9,12 +10,12 @@ import (
 )
 
 // StakeNewApplication stakes a new application with the given service ID and amount (in uPOKT).
-func (e *Executor) StakeNewApplication(appAddress, serviceID, network string, amountUpokt int64, rpcEndpoint string) (*models.TransactionResponse, error) {
+func (e *Executor) StakeNewApplication(ctx context.Context, appAddress, serviceID, network string, amountUpokt int64, rpcEndpoint string) (*models.TransactionResponse, error) {
 	if err := validate.ServiceID(serviceID); err != nil {
 		return nil, fmt.Errorf("invalid service ID: %w", err)
 	}
 
-	amountStr := fmt.Sprintf("%dupokt", amountUpokt)
+	amountStr := e.coins(network, amountUpokt)
 
 	e.Logger.Info("staking new application",
 		"address", appAddress,
@@ -53,37 +54,24 @@ func (e *Executor) StakeNewApplication(appAddress, serviceID, network string, am
 		"--chain-id", network,
 		"--yes",
 		"--gas=auto",
-		"--fees=1upokt",
+		e.feesFlag(network),
 		"--output", "json",
 	}
 
+	args = append(args, e.feeGranterArgs(ctx, appAddress, network)...)
+
 	if e.Config.Config.KeyringBackend != "" {
 		args = append(args, "--keyring-backend", e.Config.Config.KeyringBackend)
 	}
 
-	e.Logger.Debug("stake new app command", "args", args)
-
-	output, err := e.Run(args...)
-	if err != nil {
-		e.Logger.Error("stake new app command failed", "error", err)
-		return &models.TransactionResponse{
-			Success: false,
-			Message: "stake transaction failed",
-		}, nil
-	}
-
-	e.Logger.Info("stake new app transaction submitted", "output", output)
-
-	if txhash := parseTxHash(output); txhash != "" {
-		return &models.TransactionResponse{TxHash: txhash, Success: true}, nil
-	}
-
-	return &models.TransactionResponse{Success: true, Message: "Transaction submitted"}, nil
+	return e.runTx(ctx, "stake", appAddress, args)
 }
 
 // UpstakeApplication increases an application's stake by the given amount (in uPOKT).
-func (e *Executor) UpstakeApplication(appAddress, bankAddress, network string, amount int64, rpcEndpoint, apiEndpoint string) (*models.TransactionResponse, error) {
-	app, err := e.Client.QueryApplication(appAddress, apiEndpoint, network)
+// When the bank holds an active authz grant from the app, the stake is
+// submitted by the bank via tx authz exec and the app key isn't needed.
+func (e *Executor) UpstakeApplication(ctx context.Context, appAddress, bankAddress, network string, amount int64, rpcEndpoint, apiEndpoint string) (*models.TransactionResponse, error) {
+	app, err := e.Client.QueryApplication(ctx, appAddress, apiEndpoint, network, e.Config.Config.Networks[network].BaseDenom())
 	if err != nil {
 		return nil, fmt.Errorf("failed to query application before upstake: %w", err)
 	}
@@ -100,7 +88,7 @@ func (e *Executor) UpstakeApplication(appAddress, bankAddress, network string, a
 	if err != nil {
 		return nil, fmt.Errorf("invalid stake calculation: %w", err)
 	}
-	amountStr := fmt.Sprintf("%dupokt", newStakeAmount)
+	amountStr := e.coins(network, newStakeAmount)
 
 	e.Logger.Info("upstaking application",
 		"address", appAddress,
@@ -132,6 +120,11 @@ func (e *Executor) UpstakeApplication(appAddress, bankAddress, network string, a
 	}
 	defer os.Remove(tempConfig)
 
+	if e.useStakeGrant(ctx, appAddress, bankAddress, network, apiEndpoint) {
+		e.Logger.Info("upstaking through bank authz grant", "address", appAddress, "bank", bankAddress)
+		return e.upstakeViaAuthz(ctx, tempConfig, appAddress, bankAddress, network, rpcEndpoint)
+	}
+
 	args := []string{
 		"tx", "application", "stake-application",
 		"--config", tempConfig,
@@ -140,37 +133,28 @@ func (e *Executor) UpstakeApplication(appAddress, bankAddress, network string, a
 		"--chain-id", network,
 		"--yes",
 		"--gas=auto",
-		"--fees=1upokt",
+		e.feesFlag(network),
 		"--output", "json",
 	}
 
+	args = append(args, e.feeGranterArgs(ctx, appAddress, network)...)
+
 	if e.Config.Config.KeyringBackend != "" {
 		args = append(args, "--keyring-backend", e.Config.Config.KeyringBackend)
 	}
 
-	e.Logger.Debug("upstake command", "args", args)
-
-	output, err := e.Run(args...)
-	if err != nil {
-		e.Logger.Error("upstake command failed", "error", err)
-		return &models.TransactionResponse{
-			Success: false,
-			Message: "upstake transaction failed",
-		}, nil
-	}
-
-	e.Logger.Info("upstake transaction submitted", "output", output)
-
-	if txhash := parseTxHash(output); txhash != "" {
-		return &models.TransactionResponse{TxHash: txhash, Success: true}, nil
-	}
-
-	return &models.TransactionResponse{Success: true, Message: "Transaction submitted"}, nil
+	return e.runTx(ctx, "upstake", appAddress, args)
 }
 
 // FundApplication sends POKT from the bank to an application address.
-func (e *Executor) FundApplication(appAddress, bankAddress, network string, amount int64, rpcEndpoint string) (*models.TransactionResponse, error) {
-	amountStr := fmt.Sprintf("%dupokt", amount)
+// On networks whose bank is offline, the transaction is generated unsigned and
+// stored as a pending signature request instead of being broadcast.
+func (e *Executor) FundApplication(ctx context.Context, appAddress, bankAddress, network string, amount int64, rpcEndpoint string) (*models.TransactionResponse, error) {
+	if netCfg, ok := e.Config.Config.Networks[network]; ok && netCfg.OfflineBank() {
+		return e.generateFund(ctx, appAddress, bankAddress, network, amount, rpcEndpoint, netCfg)
+	}
+
+	amountStr := e.coins(network, amount)
 
 	e.Logger.Info("funding application", "address", appAddress, "amount", amountStr)
 
@@ -183,7 +167,7 @@ func (e *Executor) FundApplication(appAddress, bankAddress, network string, amou
 		"--chain-id", network,
 		"--yes",
 		"--gas=auto",
-		"--fees=1upokt",
+		e.feesFlag(network),
 		"--output", "json",
 	}
 
@@ -191,22 +175,37 @@ func (e *Executor) FundApplication(appAddress, bankAddress, network string, amou
 		args = append(args, "--keyring-backend", e.Config.Config.KeyringBackend)
 	}
 
-	e.Logger.Debug("fund command", "args", args)
+	return e.runTx(ctx, "fund", bankAddress, args)
+}
 
-	output, err := e.Run(args...)
-	if err != nil {
-		e.Logger.Error("fund command failed", "error", err)
-		return &models.TransactionResponse{
-			Success: false,
-			Message: "fund transaction failed",
-		}, nil
+// SweepApplication sends POKT from an application back to the bank address.
+func (e *Executor) SweepApplication(ctx context.Context, appAddress, bankAddress, network string, amount int64, rpcEndpoint string) (*models.TransactionResponse, error) {
+	if amount <= 0 {
+		return nil, fmt.Errorf("sweep amount must be positive")
 	}
 
-	e.Logger.Info("fund transaction submitted", "output", output)
+	amountStr := e.coins(network, amount)
 
-	if txhash := parseTxHash(output); txhash != "" {
-		return &models.TransactionResponse{TxHash: txhash, Success: true}, nil
+	e.Logger.Info("sweeping application", "address", appAddress, "bank", bankAddress, "amount", amountStr)
+
+	args := []string{
+		"tx", "bank", "send",
+		appAddress,
+		bankAddress,
+		amountStr,
+		"--node", rpcEndpoint,
+		"--chain-id", network,
+		"--yes",
+		"--gas=auto",
+		e.feesFlag(network),
+		"--output", "json",
+	}
+
+	args = append(args, e.feeGranterArgs(ctx, appAddress, network)...)
+
+	if e.Config.Config.KeyringBackend != "" {
+		args = append(args, "--keyring-backend", e.Config.Config.KeyringBackend)
 	}
 
-	return &models.TransactionResponse{Success: true, Message: "Transaction submitted"}, nil
+	return e.runTx(ctx, "sweep", appAddress, args)
 }
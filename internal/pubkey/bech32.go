@@ -0,0 +1,70 @@
+package pubkey
+
+import "strings"
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// bech32Encode encodes data as a BIP-173 bech32 string with prefix hrp.
+func bech32Encode(hrp string, data []byte) string {
+	values := convertBits(data)
+
+	checksumInput := append(hrpExpand(hrp), values...)
+	checksumInput = append(checksumInput, 0, 0, 0, 0, 0, 0)
+	mod := bech32Polymod(checksumInput) ^ 1
+
+	var sb strings.Builder
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+	for _, v := range values {
+		sb.WriteByte(bech32Charset[v])
+	}
+	for i := 0; i < 6; i++ {
+		sb.WriteByte(bech32Charset[(mod>>(5*(5-i)))&31])
+	}
+	return sb.String()
+}
+
+// convertBits regroups 8-bit bytes into zero-padded 5-bit values.
+func convertBits(data []byte) []byte {
+	var out []byte
+	acc, n := 0, 0
+	for _, b := range data {
+		acc = acc<<8 | int(b)
+		n += 8
+		for n >= 5 {
+			n -= 5
+			out = append(out, byte(acc>>n)&31)
+		}
+	}
+	if n > 0 {
+		out = append(out, byte(acc<<(5-n))&31)
+	}
+	return out
+}
+
+func hrpExpand(hrp string) []byte {
+	out := make([]byte, 0, 2*len(hrp)+1)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]>>5)
+	}
+	out = append(out, 0)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]&31)
+	}
+	return out
+}
+
+func bech32Polymod(values []byte) uint32 {
+	gen := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
@@ -0,0 +1,40 @@
+// Package pubkey derives Pocket Network account addresses from the public
+// keys found in signed transactions, so SAM can check who actually signed.
+package pubkey
+
+import (
+	"crypto/sha256"
+	"fmt"
+)
+
+// Key type URLs as they appear in Cosmos SDK JSON transactions.
+const (
+	Secp256k1Type = "/cosmos.crypto.secp256k1.PubKey"
+	MultisigType  = "/cosmos.crypto.multisig.LegacyAminoPubKey"
+)
+
+// addressPrefix is the bech32 prefix of Pocket Network account addresses.
+const addressPrefix = "pokt"
+
+// Any is a public key as encoded in a JSON transaction: a single key carries
+// Key, a multisig key carries Threshold and its member PublicKeys.
+type Any struct {
+	Type       string `json:"@type"`
+	Key        []byte `json:"key,omitempty"`
+	Threshold  int    `json:"threshold,omitempty"`
+	PublicKeys []Any  `json:"public_keys,omitempty"`
+}
+
+// Address returns the account address of a secp256k1 public key, the only
+// single-key type Pocket accounts use.
+func Address(keyType string, key []byte) (string, error) {
+	if keyType != Secp256k1Type {
+		return "", fmt.Errorf("unsupported public key type %q", keyType)
+	}
+	if len(key) != 33 {
+		return "", fmt.Errorf("secp256k1 public key must be 33 bytes, got %d", len(key))
+	}
+	sha := sha256.Sum256(key)
+	hash := ripemd160(sha[:])
+	return bech32Encode(addressPrefix, hash[:]), nil
+}
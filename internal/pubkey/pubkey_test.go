@@ -0,0 +1,52 @@
+package pubkey
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestRIPEMD160(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want string
+	}{
+		{[]byte(""), "9c1185a5c5e9fc54612808977ee8f548b2258d31"},
+		{[]byte("abc"), "8eb208f7e05d987a9b044a8e98c6b087f15a0bfc"},
+		{bytes.Repeat([]byte("a"), 1000), "aa69deee9a8922e92f8105e007f76110f381e9cf"},
+	}
+	for _, tt := range tests {
+		got := ripemd160(tt.in)
+		if hex.EncodeToString(got[:]) != tt.want {
+			t.Errorf("ripemd160(%d bytes) = %x, want %s", len(tt.in), got, tt.want)
+		}
+	}
+}
+
+func TestBech32Encode(t *testing.T) {
+	// BIP-173 test vector.
+	if got := bech32Encode("a", nil); got != "a12uel5l" {
+		t.Errorf("bech32Encode(a) = %s, want a12uel5l", got)
+	}
+}
+
+func TestAddress(t *testing.T) {
+	// Cosmos SDK secp256k1 address test key; its hash160 is
+	// 7c2bb42a8be69791ec763e51f5a49bcd41e82237.
+	key, _ := hex.DecodeString("02950e1cdfcb133d6024109fd489f734eeb4502418e538c28481f22bce276f248c")
+
+	got, err := Address(Secp256k1Type, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "pokt10s4mg25tu6termrk8egltfyme4q7sg3hy949ey"; got != want {
+		t.Errorf("Address() = %s, want %s", got, want)
+	}
+
+	if _, err := Address(Secp256k1Type, key[:32]); err == nil {
+		t.Error("expected an error for a short key")
+	}
+	if _, err := Address("/cosmos.crypto.ed25519.PubKey", key); err == nil {
+		t.Error("expected an error for an unsupported key type")
+	}
+}
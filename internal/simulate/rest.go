@@ -0,0 +1,271 @@
+package simulate
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/pokt-network/sam/internal/models"
+)
+
+// Handler returns the REST stand-in: the subset of the Pocket and Cosmos
+// query endpoints pocket.Client uses, answered from the simulated chain.
+func (s *Simulation) Handler() http.Handler {
+	r := mux.NewRouter()
+	r.HandleFunc("/cosmos/bank/v1beta1/balances/{address}", s.handleBalance).Methods("GET")
+	r.HandleFunc("/cosmos/auth/v1beta1/accounts/{address}", s.handleAccount).Methods("GET")
+	r.HandleFunc("/cosmos/tx/v1beta1/txs/{hash}", s.handleTx).Methods("GET")
+	r.HandleFunc("/cosmos/authz/v1beta1/grants", s.handleGrants).Methods("GET")
+	r.HandleFunc("/cosmos/authz/v1beta1/grants/grantee/{grantee}", s.handleGranteeGrants).Methods("GET")
+	r.HandleFunc("/cosmos/feegrant/v1beta1/allowance/{granter}/{grantee}", s.handleAllowance).Methods("GET")
+	r.HandleFunc("/cosmos/feegrant/v1beta1/issued/{granter}", s.handleIssuedAllowances).Methods("GET")
+	r.HandleFunc("/pokt-network/poktroll/application/application", s.handleApplications).Methods("GET")
+	r.HandleFunc("/pokt-network/poktroll/application/application/{address}", s.handleApplication).Methods("GET")
+	r.HandleFunc("/pokt-network/poktroll/service/service", s.handleServices).Methods("GET")
+	r.HandleFunc("/pokt-network/poktroll/application/params", s.handleApplicationParams).Methods("GET")
+	r.HandleFunc("/pokt-network/poktroll/shared/params", s.handleSharedParams).Methods("GET")
+	r.HandleFunc("/pokt-network/poktroll/tokenomics/params", s.handleTokenomicsParams).Methods("GET")
+	r.HandleFunc("/cosmos/base/tendermint/v1beta1/blocks/latest", s.handleLatestBlock).Methods("GET")
+	r.HandleFunc("/status", s.handleStatus).Methods("GET")
+	return r
+}
+
+func (s *Simulation) handleBalance(w http.ResponseWriter, r *http.Request) {
+	coins, _ := s.Chain.QueryAllBalances(r.Context(), mux.Vars(r)["address"], "")
+	writeJSON(w, http.StatusOK, models.APIBalanceResponse{Balances: coins})
+}
+
+func (s *Simulation) handleAccount(w http.ResponseWriter, r *http.Request) {
+	address := mux.Vars(r)["address"]
+	number, sequence, err := s.Chain.QueryAccount(r.Context(), address, "")
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": err.Error()})
+		return
+	}
+
+	var resp models.APIAccountResponse
+	resp.Account.Type = "/cosmos.auth.v1beta1.BaseAccount"
+	resp.Account.Address = address
+	resp.Account.AccountNumber = strconv.FormatUint(number, 10)
+	resp.Account.Sequence = strconv.FormatUint(sequence, 10)
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Simulation) handleTx(w http.ResponseWriter, r *http.Request) {
+	resp, found, _ := s.Chain.QueryTx(r.Context(), mux.Vars(r)["hash"], "")
+	if !found {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "tx not found"})
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Simulation) handleGrants(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	grant, _ := s.Chain.QueryStakeGrant(r.Context(), q.Get("granter"), q.Get("grantee"), "")
+	if grant == nil {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "authorization not found"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.APIGrantsResponse{Grants: []models.APIGrant{apiGrant(grant)}})
+}
+
+func (s *Simulation) handleGranteeGrants(w http.ResponseWriter, r *http.Request) {
+	grants, _ := s.Chain.ListStakeGrants(r.Context(), mux.Vars(r)["grantee"], "")
+	resp := models.APIGrantsResponse{Grants: []models.APIGrant{}}
+	for _, grant := range grants {
+		resp.Grants = append(resp.Grants, apiGrant(grant))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func apiGrant(grant *models.AuthzGrant) models.APIGrant {
+	g := models.APIGrant{Granter: grant.Granter, Grantee: grant.Grantee, Expiration: grant.Expiration}
+	g.Authorization.Type = "/cosmos.authz.v1beta1.GenericAuthorization"
+	g.Authorization.Msg = grant.MsgTypeURL
+	return g
+}
+
+func (s *Simulation) handleAllowance(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	allowance, _ := s.Chain.QueryFeeAllowance(r.Context(), vars["granter"], vars["grantee"], "", models.Denom)
+	if allowance == nil {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "fee-grant not found"})
+		return
+	}
+	writeJSON(w, http.StatusOK, models.APIFeeAllowanceResponse{Allowance: apiFeeGrant(allowance)})
+}
+
+func (s *Simulation) handleIssuedAllowances(w http.ResponseWriter, r *http.Request) {
+	allowances, _ := s.Chain.ListFeeAllowances(r.Context(), mux.Vars(r)["granter"], "", models.Denom)
+	resp := models.APIFeeAllowancesResponse{Allowances: []models.APIFeeGrant{}}
+	for _, allowance := range allowances {
+		resp.Allowances = append(resp.Allowances, apiFeeGrant(allowance))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func apiFeeGrant(allowance *models.FeeAllowance) models.APIFeeGrant {
+	basic := models.APIFeeAllowance{
+		Type:       "/cosmos.feegrant.v1beta1.BasicAllowance",
+		Expiration: allowance.Expiration,
+	}
+	if allowance.SpendLimit != nil {
+		basic.SpendLimit = []models.Coin{{Denom: models.Denom, Amount: strconv.FormatInt(*allowance.SpendLimit, 10)}}
+	}
+	return models.APIFeeGrant{Granter: allowance.Granter, Grantee: allowance.Grantee, Allowance: basic}
+}
+
+func (s *Simulation) handleApplication(w http.ResponseWriter, r *http.Request) {
+	address := mux.Vars(r)["address"]
+	app, err := s.Chain.QueryApplication(r.Context(), address, "", Network, models.Denom)
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": err.Error()})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, models.APIApplicationResponse{Application: apiApplication(app)})
+}
+
+func (s *Simulation) handleApplications(w http.ResponseWriter, r *http.Request) {
+	apps, _ := s.Chain.ListApplications(r.Context(), "", Network, r.URL.Query().Get("delegatee_gateway_address"))
+
+	start, end, pagination, err := paginate(r, len(apps))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return
+	}
+
+	resp := models.APIApplicationsResponse{
+		Applications: make([]models.APIApplication, 0, end-start),
+		Pagination:   pagination,
+	}
+	for _, app := range apps[start:end] {
+		resp.Applications = append(resp.Applications, apiApplication(app))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// paginate returns the slice bounds of the page of n items that r asks for
+// and its pagination response. Like a Cosmos list query it reads
+// pagination.limit and pagination.key; the key is the base64 offset of the
+// next page.
+func paginate(r *http.Request, n int) (start, end int, pagination models.APIPagination, err error) {
+	q := r.URL.Query()
+	if key := q.Get("pagination.key"); key != "" {
+		raw, err := base64.StdEncoding.DecodeString(key)
+		if err == nil {
+			start, err = strconv.Atoi(string(raw))
+		}
+		if err != nil || start < 0 || start > n {
+			return 0, 0, models.APIPagination{}, errors.New("invalid pagination key")
+		}
+	}
+	limit, err := strconv.Atoi(q.Get("pagination.limit"))
+	if err != nil || limit <= 0 {
+		limit = 100
+	}
+
+	end = min(start+limit, n)
+	pagination.Total = strconv.Itoa(n)
+	if end < n {
+		pagination.NextKey = base64.StdEncoding.EncodeToString([]byte(strconv.Itoa(end)))
+	}
+	return start, end, pagination, nil
+}
+
+func apiApplication(app *models.Application) models.APIApplication {
+	entry := models.APIApplication{
+		Address:                   app.Address,
+		Stake:                     &models.Coin{Denom: models.Denom, Amount: strconv.FormatInt(app.Stake, 10)},
+		DelegateeGatewayAddresses: app.Gateways,
+		PendingUndelegations:      map[string]models.APIUndelegatingGatewayList{},
+		UnstakeSessionEndHeight:   strconv.FormatInt(app.UnstakeSessionEndHeight, 10),
+	}
+	for _, id := range app.ServiceIDs {
+		entry.ServiceConfigs = append(entry.ServiceConfigs, models.ServiceConfig{ServiceID: id})
+	}
+	return entry
+}
+
+func (s *Simulation) handleServices(w http.ResponseWriter, r *http.Request) {
+	services, _ := s.Chain.QueryServices(r.Context(), "")
+
+	start, end, pagination, err := paginate(r, len(services))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return
+	}
+
+	resp := models.APIServicesResponse{
+		Service:    make([]models.APIServiceEntry, 0, end-start),
+		Pagination: pagination,
+	}
+	for _, svc := range services[start:end] {
+		resp.Service = append(resp.Service, models.APIServiceEntry{ID: svc.ID, Name: svc.Name})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Simulation) handleApplicationParams(w http.ResponseWriter, r *http.Request) {
+	params, _ := s.Chain.QueryApplicationParams(r.Context(), "")
+	var resp models.APIApplicationParamsResponse
+	resp.Params.MaxDelegatedGateways = strconv.FormatInt(params.MaxDelegatedGateways, 10)
+	resp.Params.MinStake = &models.Coin{Denom: models.Denom, Amount: strconv.FormatInt(params.MinStake, 10)}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Simulation) handleSharedParams(w http.ResponseWriter, r *http.Request) {
+	params, _ := s.Chain.QuerySharedParams(r.Context(), "")
+	var resp models.APISharedParamsResponse
+	p := &resp.Params
+	p.NumBlocksPerSession = strconv.FormatInt(params.NumBlocksPerSession, 10)
+	p.GracePeriodEndOffsetBlocks = strconv.FormatInt(params.GracePeriodEndOffsetBlocks, 10)
+	p.ClaimWindowOpenOffsetBlocks = strconv.FormatInt(params.ClaimWindowOpenOffsetBlocks, 10)
+	p.ClaimWindowCloseOffsetBlocks = strconv.FormatInt(params.ClaimWindowCloseOffsetBlocks, 10)
+	p.ProofWindowOpenOffsetBlocks = strconv.FormatInt(params.ProofWindowOpenOffsetBlocks, 10)
+	p.ProofWindowCloseOffsetBlocks = strconv.FormatInt(params.ProofWindowCloseOffsetBlocks, 10)
+	p.SupplierUnbondingPeriodSessions = strconv.FormatInt(params.SupplierUnbondingPeriodSessions, 10)
+	p.ApplicationUnbondingPeriodSessions = strconv.FormatInt(params.ApplicationUnbondingPeriodSessions, 10)
+	p.ComputeUnitsToTokensMultiplier = strconv.FormatInt(params.ComputeUnitsToTokensMultiplier, 10)
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Simulation) handleTokenomicsParams(w http.ResponseWriter, r *http.Request) {
+	params, _ := s.Chain.QueryTokenomicsParams(r.Context(), "")
+	writeJSON(w, http.StatusOK, models.APITokenomicsParamsResponse{Params: *params})
+}
+
+func (s *Simulation) handleLatestBlock(w http.ResponseWriter, r *http.Request) {
+	block, _ := s.Chain.QueryLatestBlock(r.Context(), "")
+	writeJSON(w, http.StatusOK, map[string]any{
+		"block": map[string]any{"header": map[string]any{
+			"height": strconv.FormatInt(block.Height, 10),
+			"time":   block.BlockTime,
+		}},
+	})
+}
+
+// handleStatus answers the CometBFT RPC status query used to probe RPC
+// endpoints; the simulated API and RPC share one listener.
+func (s *Simulation) handleStatus(w http.ResponseWriter, r *http.Request) {
+	block, _ := s.Chain.QueryLatestBlock(r.Context(), "")
+	writeJSON(w, http.StatusOK, map[string]any{
+		"result": map[string]any{"sync_info": map[string]any{
+			"latest_block_height": strconv.FormatInt(block.Height, 10),
+			"latest_block_time":   block.BlockTime,
+			"catching_up":         false,
+		}},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, code int, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(payload)
+}
@@ -0,0 +1,187 @@
+// Package simulate runs SAM against an in-memory chain for demos and local
+// development: a local stand-in for the Pocket REST API backed by a
+// fake.Chain, seeded with applications whose stakes burn down over time.
+package simulate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pokt-network/sam/internal/config"
+	"github.com/pokt-network/sam/internal/models"
+	"github.com/pokt-network/sam/internal/pocket/fake"
+)
+
+// Network is the name of the simulated network.
+const Network = "pocket"
+
+const upoktPerPOKT = 1_000_000
+
+// seedApp describes a simulated application.
+type seedApp struct {
+	name      string
+	serviceID string
+	stake     int64 // POKT
+	liquid    int64 // POKT
+	burn      int64 // POKT per tick
+}
+
+var seedApps = []seedApp{
+	{"app1", "anvil", 3000, 0, 2},
+	{"app2", "eth", 2400, 50, 5},
+	{"app3", "base", 2050, 0, 8},
+	{"app4", "poly", 1600, 200, 4},
+	{"app5", "eth", 1150, 0, 6},
+	{"app6", "anvil", 900, 0, 3},
+}
+
+var seedServices = []models.ServiceInfo{
+	{ID: "anvil", Name: "Anvil"},
+	{ID: "base", Name: "Base"},
+	{ID: "eth", Name: "Ethereum"},
+	{ID: "poly", Name: "Polygon"},
+}
+
+// Simulation is a seeded in-memory chain plus the burn-down schedule.
+type Simulation struct {
+	Chain   *fake.Chain
+	Bank    string
+	Gateway string
+	Apps    []string
+
+	// Untracked is delegated to Gateway but left out of Config, so
+	// discovery has an app to report.
+	Untracked string
+
+	// TickInterval is how often stakes burn down.
+	TickInterval time.Duration
+
+	burn   map[string]int64 // uPOKT per tick
+	Logger *slog.Logger
+}
+
+// Address returns a valid-looking simulated pokt address for name.
+func Address(name string) string {
+	return "pokt1" + name + strings.Repeat("0", 38-len(name))
+}
+
+// New returns a seeded simulation: a funded bank, a gateway, the services
+// and applications above, and one more app for discovery to find.
+func New(logger *slog.Logger) *Simulation {
+	s := &Simulation{
+		Chain:        fake.New(),
+		Bank:         Address("simbank"),
+		Gateway:      Address("simgateway"),
+		Untracked:    Address("simapp7"),
+		TickInterval: 10 * time.Second,
+		burn:         make(map[string]int64),
+		Logger:       logger,
+	}
+
+	s.Chain.SetMinStake(100 * upoktPerPOKT)
+	s.Chain.SetBalance(s.Bank, 50_000*upoktPerPOKT)
+	// A second denom, so the multi-denom balances show up in the UI.
+	s.Chain.SetCoins(s.Bank, models.Coin{Denom: "uusdc", Amount: "2500000000"})
+	for _, svc := range seedServices {
+		s.Chain.AddService(svc.ID, svc.Name)
+	}
+	for _, a := range seedApps {
+		addr := Address("sim" + a.name)
+		s.Chain.SetApplication(addr, a.serviceID, a.stake*upoktPerPOKT)
+		s.Chain.SetBalance(addr, a.liquid*upoktPerPOKT)
+		s.Chain.SetGateway(addr, s.Gateway)
+		s.burn[addr] = a.burn * upoktPerPOKT
+		s.Apps = append(s.Apps, addr)
+	}
+	s.Chain.SetApplication(s.Untracked, "base", 1800*upoktPerPOKT)
+	s.Chain.SetGateway(s.Untracked, s.Gateway)
+
+	return s
+}
+
+// Config returns a SAM config with one network whose endpoints point at the
+// REST stand-in served at apiURL.
+func (s *Simulation) Config(apiURL string) *config.Config {
+	cfg := &config.Config{}
+	cfg.Config.Thresholds = config.Thresholds{
+		WarningThreshold: 2000 * upoktPerPOKT,
+		DangerThreshold:  1000 * upoktPerPOKT,
+	}
+	cfg.Config.Networks = map[string]config.NetworkConfig{
+		Network: {
+			RPCEndpoint:  apiURL,
+			APIEndpoint:  apiURL,
+			Gateways:     []string{s.Gateway},
+			Bank:         s.Bank,
+			Applications: append([]string(nil), s.Apps...),
+		},
+	}
+	return cfg
+}
+
+// AutoTopUps returns demo auto top-up policies for the first seeded apps,
+// so the worker has something to do.
+func (s *Simulation) AutoTopUps() map[string]models.AutoTopUpConfig {
+	return map[string]models.AutoTopUpConfig{
+		s.Apps[2]: {Enabled: true, TriggerThreshold: 2000 * upoktPerPOKT, TargetAmount: 2500 * upoktPerPOKT},
+		s.Apps[4]: {Enabled: true, TriggerThreshold: 1000 * upoktPerPOKT, TargetAmount: 2000 * upoktPerPOKT},
+	}
+}
+
+// Start serves the REST stand-in on a free loopback port and returns its
+// base URL. The listener is closed when ctx is cancelled.
+func (s *Simulation) Start(ctx context.Context) (string, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", fmt.Errorf("failed to listen for simulated API: %w", err)
+	}
+
+	srv := &http.Server{
+		Handler:      s.Handler(),
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.Logger.Error("simulated API stopped", "error", err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+
+	return "http://" + ln.Addr().String(), nil
+}
+
+// Run burns down application stakes every TickInterval until ctx is cancelled.
+func (s *Simulation) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.TickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Tick()
+		}
+	}
+}
+
+// Tick commits a block and burns one interval's worth of stake from every
+// application.
+func (s *Simulation) Tick() {
+	s.Chain.NextBlock()
+	for _, addr := range s.Apps {
+		stake := s.Chain.Burn(addr, s.burn[addr])
+		s.Logger.Debug("simulated stake burn", "address", addr, "stake", stake)
+	}
+}
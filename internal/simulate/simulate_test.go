@@ -0,0 +1,97 @@
+package simulate
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pokt-network/sam/internal/config"
+	"github.com/pokt-network/sam/internal/models"
+	"github.com/pokt-network/sam/internal/pocket"
+	"github.com/pokt-network/sam/internal/validate"
+)
+
+func newTestSimulation(t *testing.T) (*Simulation, *pocket.Client, string) {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	sim := New(logger)
+	srv := httptest.NewServer(sim.Handler())
+	t.Cleanup(srv.Close)
+	return sim, pocket.NewClient(logger), srv.URL
+}
+
+func TestSimulation_SeedAddressesValid(t *testing.T) {
+	sim, _, _ := newTestSimulation(t)
+
+	for _, addr := range append([]string{sim.Bank, sim.Gateway}, sim.Apps...) {
+		if err := validate.Address(addr); err != nil {
+			t.Errorf("seed address %s: %v", addr, err)
+		}
+	}
+}
+
+func TestSimulation_RESTStandIn(t *testing.T) {
+	sim, client, url := newTestSimulation(t)
+
+	app, err := client.QueryApplication(context.Background(), sim.Apps[0], url, Network, models.Denom)
+	if err != nil {
+		t.Fatalf("QueryApplication() error = %v", err)
+	}
+	if app.Stake != 3000*upoktPerPOKT || app.ServiceID != "anvil" || app.Gateway != sim.Gateway {
+		t.Errorf("application = %+v", app)
+	}
+
+	bank, err := client.QueryBankAccount(context.Background(), sim.Bank, url, Network, models.Denom)
+	if err != nil || bank.Balance != 50_000*upoktPerPOKT || len(bank.Balances) != 2 {
+		t.Errorf("QueryBankAccount() = %+v, %v", bank, err)
+	}
+
+	params, err := pocket.QueryChainParams(context.Background(), client, Network, config.NetworkConfig{APIEndpoint: url})
+	if err != nil || params.Application.MinStake != 100*upoktPerPOKT || params.Shared.NumBlocksPerSession == 0 || len(params.Tokenomics.MintAllocationPercentages) == 0 {
+		t.Errorf("QueryChainParams() = %+v, %v", params, err)
+	}
+
+	services, err := client.QueryServices(context.Background(), url)
+	if err != nil || len(services) != len(seedServices) {
+		t.Errorf("QueryServices() = %v, %v", services, err)
+	}
+
+	if _, err := client.QueryApplication(context.Background(), Address("nosuchapp"), url, Network, models.Denom); err == nil {
+		t.Error("expected error for unknown application")
+	}
+
+	result, _ := sim.Chain.FundApplication(context.Background(), sim.Apps[0], sim.Bank, Network, 10, "")
+	tx, found, err := client.QueryTx(context.Background(), result.TxHash, url)
+	if err != nil || !found || tx.TxResponse.Code != 0 {
+		t.Errorf("QueryTx() = %+v, %v, %v", tx, found, err)
+	}
+
+	apps, err := client.ListApplications(context.Background(), url, Network, sim.Gateway)
+	if err != nil || len(apps) != len(sim.Apps)+1 {
+		t.Errorf("ListApplications() = %d apps, %v; want %d tracked and 1 untracked", len(apps), err, len(sim.Apps))
+	}
+	if apps, _ := client.ListApplications(context.Background(), url, Network, Address("othergateway")); len(apps) != 0 {
+		t.Errorf("ListApplications() for another gateway = %d apps, want 0", len(apps))
+	}
+
+	if grant, err := client.QueryStakeGrant(context.Background(), sim.Apps[0], sim.Bank, url); err != nil || grant != nil {
+		t.Errorf("QueryStakeGrant() = %+v, %v; want no grant", grant, err)
+	}
+	sim.Chain.GrantFeeAllowance(context.Background(), sim.Apps[0], sim.Bank, Network, 1000, 0, "")
+	allowance, err := client.QueryFeeAllowance(context.Background(), sim.Bank, sim.Apps[0], url, models.Denom)
+	if err != nil || allowance == nil || *allowance.SpendLimit != 1000 {
+		t.Errorf("QueryFeeAllowance() = %+v, %v", allowance, err)
+	}
+}
+
+func TestSimulation_TickBurnsStake(t *testing.T) {
+	sim, _, _ := newTestSimulation(t)
+
+	before := sim.Chain.Stake(sim.Apps[1])
+	sim.Tick()
+	if got := sim.Chain.Stake(sim.Apps[1]); got != before-seedApps[1].burn*upoktPerPOKT {
+		t.Errorf("stake after tick = %d, want %d", got, before-seedApps[1].burn*upoktPerPOKT)
+	}
+}
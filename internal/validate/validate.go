@@ -3,14 +3,18 @@ package validate
 import (
 	"errors"
 	"fmt"
-	"math"
 	"net/url"
 	"regexp"
+
+	"github.com/pokt-network/sam/internal/models"
 )
 
 var (
 	addressRe   = regexp.MustCompile(`^pokt1[a-z0-9]{38}$`)
 	serviceIDRe = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+	pendingIDRe = regexp.MustCompile(`^[a-f0-9]{16}$`)
+	keyNameRe   = regexp.MustCompile(`^[a-zA-Z0-9_.-]{1,64}$`)
+	denomRe     = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9/:._-]{2,127}$`)
 
 	allowedKeyringBackends = map[string]bool{
 		"test":    true,
@@ -37,20 +41,64 @@ func ServiceID(id string) error {
 	return nil
 }
 
-// POKTAmount validates a POKT amount and converts it to uPOKT.
-// Rejects negative, zero, NaN, Inf, and values that would overflow int64 in uPOKT.
-func POKTAmount(pokt float64) (int64, error) {
-	if math.IsNaN(pokt) || math.IsInf(pokt, 0) {
-		return 0, errors.New("amount must be a finite number")
+// KeyName validates a keyring key name passed to pocketd.
+func KeyName(name string) error {
+	if !keyNameRe.MatchString(name) || name[0] == '-' {
+		return errors.New("invalid key name: must be 1-64 alphanumeric, dot, dash, or underscore characters and not start with a dash")
 	}
-	if pokt <= 0 {
+	return nil
+}
+
+// Denom validates a Cosmos SDK coin denomination, such as "upokt" or
+// "ibc/27394FB0...".
+func Denom(denom string) error {
+	if !denomRe.MatchString(denom) {
+		return errors.New("invalid denom: must be 3-128 characters, start with a letter, and contain only letters, digits, '/', ':', '.', '_' or '-'")
+	}
+	return nil
+}
+
+// PendingID validates a pending transaction ID (16 lowercase hex characters).
+func PendingID(id string) error {
+	if !pendingIDRe.MatchString(id) {
+		return errors.New("invalid pending transaction ID")
+	}
+	return nil
+}
+
+// POKTAmount validates an amount in the network's base denom and returns
+// it in base units. Rejects other denoms, negative, zero, and values that
+// would overflow int64.
+func POKTAmount(amount models.Amount, denom string) (int64, error) {
+	amount, err := amount.In(denom)
+	if err != nil {
+		return 0, err
+	}
+	if amount.Sign() <= 0 {
 		return 0, errors.New("amount must be positive")
 	}
-	upokt := pokt * 1_000_000
-	if upokt > float64(math.MaxInt64) {
+	upokt, ok := amount.Int64()
+	if !ok {
 		return 0, errors.New("amount too large")
 	}
-	return int64(upokt), nil
+	return upokt, nil
+}
+
+// POKTFloor validates a non-negative amount (such as a balance floor) in
+// the network's base denom and returns it in base units. Unlike POKTAmount,
+// zero is accepted.
+func POKTFloor(amount models.Amount, denom string) (int64, error) {
+	amount, err := amount.In(denom)
+	if err != nil {
+		return 0, err
+	}
+	if amount.Sign() == 0 {
+		return 0, nil
+	}
+	if amount.Sign() < 0 {
+		return 0, errors.New("amount must not be negative")
+	}
+	return POKTAmount(amount, denom)
 }
 
 // StakeAddition checks that adding delta to current does not overflow int64.
@@ -65,6 +113,19 @@ func StakeAddition(current, delta int64) (int64, error) {
 	return sum, nil
 }
 
+// AmountSum adds two non-negative base-unit amounts, reporting an error
+// instead of wrapping when the sum does not fit in an int64.
+func AmountSum(a, b int64) (int64, error) {
+	if a < 0 || b < 0 {
+		return 0, fmt.Errorf("amounts must not be negative: %d, %d", a, b)
+	}
+	sum := a + b
+	if sum < a {
+		return 0, fmt.Errorf("amount overflow: %d + %d exceeds maximum", a, b)
+	}
+	return sum, nil
+}
+
 // KeyringBackend validates that the backend is in the allowed set.
 func KeyringBackend(backend string) error {
 	if !allowedKeyringBackends[backend] {
@@ -3,6 +3,8 @@ package validate
 import (
 	"math"
 	"testing"
+
+	"github.com/pokt-network/sam/internal/models"
 )
 
 func TestAddress(t *testing.T) {
@@ -72,31 +74,117 @@ func TestServiceID(t *testing.T) {
 func TestPOKTAmount(t *testing.T) {
 	tests := []struct {
 		name      string
-		pokt      float64
+		amount    string
+		wantUpokt int64
+		wantErr   bool
+	}{
+		{"1 POKT", "1", 1_000_000, false},
+		{"fractional", "0.5", 500_000, false},
+		{"small amount", "0.000001", 1, false},
+		{"large valid", "1000000", 1_000_000_000_000, false},
+		{"exact decimals", "1234.567891", 1_234_567_891, false},
+		{"upokt", "1234567891upokt", 1_234_567_891, false},
+		{"max int64 upokt", "9223372036854775807upokt", math.MaxInt64, false},
+		{"zero", "0", 0, true},
+		{"negative", "-1", 0, true},
+		{"overflow", "9223372036854775808upokt", 0, true},
+		{"overflow POKT", "9223372036854775807", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, err := models.ParseAmount(tt.amount, models.Denom)
+			if err != nil {
+				t.Fatalf("ParseAmount(%q) error = %v", tt.amount, err)
+			}
+			got, err := POKTAmount(amount, models.Denom)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("POKTAmount(%s) error = %v, wantErr %v", tt.amount, err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got != tt.wantUpokt {
+				t.Errorf("POKTAmount(%s) = %d, want %d", tt.amount, got, tt.wantUpokt)
+			}
+		})
+	}
+}
+
+func TestPOKTFloor(t *testing.T) {
+	tests := []struct {
+		name      string
+		amount    string
 		wantUpokt int64
 		wantErr   bool
 	}{
-		{"1 POKT", 1.0, 1_000_000, false},
-		{"fractional", 0.5, 500_000, false},
-		{"small amount", 0.000001, 1, false},
-		{"large valid", 1000000.0, 1_000_000_000_000, false},
-		{"zero", 0, 0, true},
-		{"negative", -1.0, 0, true},
-		{"NaN", math.NaN(), 0, true},
-		{"positive infinity", math.Inf(1), 0, true},
-		{"negative infinity", math.Inf(-1), 0, true},
-		{"overflow", float64(math.MaxInt64), 0, true},
+		{"zero", "0", 0, false},
+		{"1 POKT", "1", 1_000_000, false},
+		{"negative", "-1", 0, true},
+		{"overflow", "9223372036854775808upokt", 0, true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := POKTAmount(tt.pokt)
+			amount, err := models.ParseAmount(tt.amount, models.Denom)
+			if err != nil {
+				t.Fatalf("ParseAmount(%q) error = %v", tt.amount, err)
+			}
+			got, err := POKTFloor(amount, models.Denom)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("POKTAmount(%v) error = %v, wantErr %v", tt.pokt, err, tt.wantErr)
+				t.Errorf("POKTFloor(%s) error = %v, wantErr %v", tt.amount, err, tt.wantErr)
 				return
 			}
 			if !tt.wantErr && got != tt.wantUpokt {
-				t.Errorf("POKTAmount(%v) = %d, want %d", tt.pokt, got, tt.wantUpokt)
+				t.Errorf("POKTFloor(%s) = %d, want %d", tt.amount, got, tt.wantUpokt)
+			}
+		})
+	}
+}
+
+func TestKeyName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"simple", "bank", false},
+		{"with punctuation", "bank-multisig_v1.2", false},
+		{"empty", "", true},
+		{"leading dash", "--home", true},
+		{"space", "bank key", true},
+		{"shell metachar", "bank;rm", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := KeyName(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("KeyName(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDenom(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"upokt", "upokt", false},
+		{"ibc", "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2", false},
+		{"factory", "factory/pokt1abc/ufoo", false},
+		{"too short", "up", true},
+		{"leading digit", "1upokt", true},
+		{"leading dash", "-upokt", true},
+		{"space", "u pokt", true},
+		{"empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Denom(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Denom(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
 			}
 		})
 	}
@@ -134,6 +222,34 @@ func TestStakeAddition(t *testing.T) {
 	}
 }
 
+func TestAmountSum(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    int64
+		want    int64
+		wantErr bool
+	}{
+		{"normal addition", 1000, 500, 1500, false},
+		{"zero", 1000, 0, 1000, false},
+		{"at maximum", math.MaxInt64 - 1, 1, math.MaxInt64, false},
+		{"overflow", math.MaxInt64, 1, 0, true},
+		{"negative", 1000, -1, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AmountSum(tt.a, tt.b)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AmountSum(%d, %d) error = %v, wantErr %v", tt.a, tt.b, err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("AmountSum(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestKeyringBackend(t *testing.T) {
 	tests := []struct {
 		name    string